@@ -0,0 +1,25 @@
+package main
+
+import (
+	"crypto/hmac"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// adminAuth only lets requests bearing ADMIN_TOKEN through.
+func adminAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := []byte("Bearer " + token)
+
+		if !hmac.Equal([]byte(c.GetHeader("Authorization")), expected) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "a valid admin token is required",
+			})
+
+			return
+		}
+
+		c.Next()
+	}
+}
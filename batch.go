@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"github.com/united-drivers/go-revtc/revtc"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// batchWriteMargin leaves time to write the last results of a batch that
+// ran until its timeout.
+const batchWriteMargin = 10 * time.Second
+
+type batchRequest struct {
+	// Identifiers holds SIRENs, SIRETs and registration numbers as strings
+	// and record ids as numbers.
+	Identifiers []json.RawMessage `json:"identifiers"`
+}
+
+type batchItem struct {
+	Index      int          `json:"index"`
+	Identifier string       `json:"identifier"`
+	Kind       string       `json:"kind,omitempty"`
+	Entry      *pb.VTCEntry `json:"entry,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	Message    string       `json:"message,omitempty"`
+}
+
+func newBatchItem(index int, identifier string, entry pb.VTCEntry, err error) batchItem {
+	item := batchItem{Index: index, Identifier: identifier}
+
+	if err != nil {
+		_, item.Error = errorKind(err)
+		item.Message = err.Error()
+	} else {
+		item.Entry = &entry
+	}
+
+	return item
+}
+
+// parseBatchIdentifier reads a batch identifier: a JSON number is a record
+// id, a JSON string is parsed by revtc.ParseIdentifier.
+func parseBatchIdentifier(raw json.RawMessage) (string, revtc.Identifier, error) {
+	var value string
+
+	if err := json.Unmarshal(raw, &value); err == nil {
+		id, err := revtc.ParseIdentifier(value)
+
+		return value, id, err
+	}
+
+	var recordId int
+
+	if err := json.Unmarshal(raw, &recordId); err != nil || recordId < 1 {
+		return string(raw), revtc.Identifier{}, &revtc.InvalidInputError{Field: "identifier", Reason: "must be a string or a positive integer"}
+	}
+
+	value = strconv.Itoa(recordId)
+
+	return value, revtc.Identifier{Kind: revtc.IdentifierRecordId, Value: value}, nil
+}
+
+// httpBatch looks up many operators at once. Results are returned as a JSON
+// document in the order of the request, or streamed as NDJSON in completion
+// order when the client accepts application/x-ndjson.
+func httpBatch(c *gin.Context) {
+	var request batchRequest
+
+	if err := json.NewDecoder(c.Request.Body).Decode(&request); err != nil {
+		httpError(c, &revtc.InvalidInputError{Field: "body", Reason: err.Error()})
+
+		return
+	}
+
+	if len(request.Identifiers) == 0 {
+		httpError(c, &revtc.InvalidInputError{Field: "identifiers", Reason: "at least one identifier is required"})
+
+		return
+	}
+
+	if len(request.Identifiers) > batchSettings.maxSize {
+		httpError(c, &revtc.InvalidInputError{Field: "identifiers", Reason: fmt.Sprintf("at most %d identifiers are accepted", batchSettings.maxSize)})
+
+		return
+	}
+
+	identifiers := make([]string, len(request.Identifiers))
+	items := make([]batchItem, len(request.Identifiers))
+	ids := []revtc.Identifier{}
+	indexes := []int{}
+
+	for index, raw := range request.Identifiers {
+		identifier, id, err := parseBatchIdentifier(raw)
+		identifiers[index] = identifier
+
+		if err != nil {
+			items[index] = newBatchItem(index, identifier, pb.VTCEntry{}, err)
+
+			continue
+		}
+
+		items[index].Kind = id.Kind.String()
+		ids = append(ids, id)
+		indexes = append(indexes, index)
+	}
+
+	stream := strings.Contains(c.GetHeader("Accept"), ndjsonContentType)
+	encoder := json.NewEncoder(c.Writer)
+
+	if stream {
+		c.Header("Content-Type", ndjsonContentType)
+		c.Status(http.StatusOK)
+
+		for index, item := range items {
+			if item.Error != "" {
+				encoder.Encode(items[index])
+			}
+		}
+
+		c.Writer.Flush()
+	}
+
+	failed := len(request.Identifiers) - len(ids)
+
+	for result := range client.Batch(c.Request.Context(), ids, batchSettings.concurrency) {
+		index := indexes[result.Index]
+		kind := items[index].Kind
+		items[index] = newBatchItem(index, identifiers[index], result.Entry, result.Err)
+		items[index].Kind = kind
+
+		if result.Err != nil {
+			failed++
+		}
+
+		if stream {
+			encoder.Encode(items[index])
+			c.Writer.Flush()
+		}
+	}
+
+	if stream {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":   len(items),
+		"failed":  failed,
+		"results": items,
+	})
+}
+
+// batchWriteDeadline lets POST /batch write its response for as long as the
+// batch may run: the server write timeout is sized for single lookups. The
+// deadline is set on the connection before gin wraps the ResponseWriter,
+// which hides it.
+func batchWriteDeadline(handler http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/batch" {
+			deadline := time.Now().Add(timeout + batchWriteMargin)
+
+			if err := http.NewResponseController(w).SetWriteDeadline(deadline); err != nil {
+				log.Printf("batch: cannot extend the write deadline: %v", err)
+			}
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
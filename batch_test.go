@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/united-drivers/go-revtc/revtc"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBatchWriteDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.POST("/batch", func(c *gin.Context) {
+		// a batch writing its first result after the server write timeout
+		time.Sleep(200 * time.Millisecond)
+
+		c.Header("Content-Type", ndjsonContentType)
+		c.Writer.WriteString("{\"index\":0}\n")
+		c.Writer.Flush()
+	})
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		written bool
+	}{
+		{"server write timeout", r, false},
+		{"batch timeout", batchWriteDeadline(r, time.Second), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(test.handler)
+			server.Config.WriteTimeout = 50 * time.Millisecond
+			server.Start()
+			defer server.Close()
+
+			res, err := http.Post(server.URL+"/batch", "application/json", strings.NewReader(`{"identifiers":[1]}`))
+
+			var body []byte
+
+			if err == nil {
+				body, err = ioutil.ReadAll(res.Body)
+				res.Body.Close()
+			}
+
+			if written := err == nil && string(body) == "{\"index\":0}\n"; written != test.written {
+				t.Errorf("response written = %v, want %v (body %q, error %v)", written, test.written, body, err)
+			}
+		})
+	}
+}
+
+func TestParseBatchIdentifier(t *testing.T) {
+	tests := []struct {
+		raw   string
+		kind  revtc.IdentifierKind
+		valid bool
+	}{
+		{`"732829320"`, revtc.IdentifierCompanyNumber, true},
+		{`"EVTC075123456"`, revtc.IdentifierRegistrationNumber, true},
+		{`123456`, revtc.IdentifierRecordId, true},
+		{`"73282932"`, 0, false},
+		{`"123456"`, 0, false},
+		{`0`, 0, false},
+		{`true`, 0, false},
+	}
+
+	for _, test := range tests {
+		_, id, err := parseBatchIdentifier(json.RawMessage(test.raw))
+
+		if test.valid != (err == nil) || test.valid && id.Kind != test.kind {
+			t.Errorf("parseBatchIdentifier(%s) = %v, %v", test.raw, id, err)
+		}
+	}
+}
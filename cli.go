@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"github.com/united-drivers/go-revtc/revtc"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const usage = `usage: revtc <command> [flags]
+
+commands:
+  serve                          run the HTTP and gRPC servers (default)
+  lookup --siren N | --reg EVTC  look an operator up by SIREN or registration number
+  record ID                      fetch a registry record by its dossier.id
+  search --city Lyon ...         search the registry
+
+lookup, record and search accept --format table|json|csv.
+`
+
+var entryColumns = []string{"record_id", "company_number", "registration_number", "name", "city", "expiration_date", "validity"}
+
+// runCLI runs the command named by args[0] against client, and returns the
+// process exit code.
+func runCLI(args []string, stdout, stderr io.Writer) int {
+	var err error
+
+	switch args[0] {
+	case "lookup":
+		err = cliLookup(args[1:], stdout, stderr)
+	case "record":
+		err = cliRecord(args[1:], stdout, stderr)
+	case "search":
+		err = cliSearch(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+
+		return 2
+	}
+
+	if err == flag.ErrHelp {
+		return 0
+	}
+
+	if err, ok := err.(usageError); ok {
+		// the flag package already reported parse errors
+		if err != "" {
+			fmt.Fprintf(stderr, "%v\n\n%s", err, usage)
+		}
+
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "revtc: %v\n", err)
+
+		return 1
+	}
+
+	return 0
+}
+
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "table", "output format: table, json or csv")
+
+	return flags, format
+}
+
+func parseFlags(flags *flag.FlagSet, format *string, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+
+		return usageError("")
+	}
+
+	switch *format {
+	case "table", "json", "csv":
+		return nil
+	}
+
+	return usageError(fmt.Sprintf("unknown format %q", *format))
+}
+
+func cliLookup(args []string, stdout, stderr io.Writer) error {
+	flags, format := newFlagSet("lookup", stderr)
+	siren := flags.String("siren", "", "SIREN or SIRET of the operator")
+	registrationNumber := flags.String("reg", "", "EVTC registration number of the operator")
+
+	if err := parseFlags(flags, format, args); err != nil {
+		return err
+	}
+
+	if (*siren == "") == (*registrationNumber == "") {
+		return usageError("lookup needs exactly one of --siren and --reg")
+	}
+
+	var entry pb.VTCEntry
+	var err error
+
+	if *siren != "" {
+		entry, err = client.GetByCompanyNumber(context.Background(), *siren)
+	} else {
+		entry, err = client.GetByRegistrationNumber(context.Background(), *registrationNumber)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return printEntries(stdout, *format, entry, []pb.VTCEntry{entry})
+}
+
+func cliRecord(args []string, stdout, stderr io.Writer) error {
+	flags, format := newFlagSet("record", stderr)
+
+	// flags may also follow the record id: parse up to each positional
+	// argument, then carry on with the arguments after it
+	var positionals []string
+
+	for {
+		if err := parseFlags(flags, format, args); err != nil {
+			return err
+		}
+
+		if flags.NArg() == 0 {
+			break
+		}
+
+		positionals = append(positionals, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+
+	if len(positionals) != 1 {
+		return usageError("record needs a record id")
+	}
+
+	recordId, err := strconv.Atoi(positionals[0])
+
+	if err != nil || recordId < 1 {
+		return &revtc.InvalidInputError{Field: "record id", Reason: "must be a positive integer"}
+	}
+
+	entry, err := client.GetByRecordId(context.Background(), recordId)
+
+	if err != nil {
+		return err
+	}
+
+	return printEntries(stdout, *format, entry, []pb.VTCEntry{entry})
+}
+
+func cliSearch(args []string, stdout, stderr io.Writer) error {
+	flags, format := newFlagSet("search", stderr)
+	page := flags.Int("page", 1, "page of the result list to fetch")
+	criteria := map[revtc.APISearchParams]*string{}
+
+	for name, param := range searchQueryParams {
+		criteria[param] = flags.String(strings.Replace(name, "_", "-", -1), "", "search by "+strings.Replace(name, "_", " ", -1))
+	}
+
+	if err := parseFlags(flags, format, args); err != nil {
+		return err
+	}
+
+	params := map[revtc.APISearchParams]string{}
+
+	for param, value := range criteria {
+		if *value != "" {
+			params[param] = *value
+		}
+	}
+
+	if len(params) == 0 {
+		return usageError("search needs at least one criterion")
+	}
+
+	if *page < 1 {
+		return &revtc.InvalidInputError{Field: "page", Reason: "must be a positive integer"}
+	}
+
+	result, err := client.SearchPage(context.Background(), params, *page)
+
+	if err != nil {
+		return err
+	}
+
+	if err := printEntries(stdout, *format, result, result.Entries); err != nil {
+		return err
+	}
+
+	if result.HasMore && *format != "json" {
+		fmt.Fprintf(stderr, "%d operators match, run again with --page %d for more\n", result.TotalCount, result.Page+1)
+	}
+
+	return nil
+}
+
+// printEntries writes entries as a table or CSV, or value as JSON.
+func printEntries(w io.Writer, format string, value interface{}, entries []pb.VTCEntry) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write(entryColumns)
+
+		for i := range entries {
+			writer.Write(entryRow(&entries[i]))
+		}
+
+		writer.Flush()
+
+		return writer.Error()
+
+	case "table":
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(entryColumns, "\t")))
+
+		for i := range entries {
+			fmt.Fprintln(writer, strings.Join(entryRow(&entries[i]), "\t"))
+		}
+
+		return writer.Flush()
+	}
+
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func entryRow(entry *pb.VTCEntry) []string {
+	name := ""
+
+	if company := entry.GetCompany(); company != nil {
+		name = company.GetName()
+	} else if individual := entry.GetIndividual(); individual != nil {
+		name = strings.TrimSpace(individual.GetName().GetFirstName() + " " + individual.GetName().GetLastName())
+	}
+
+	expirationDate := entry.GetExpirationDateRaw()
+
+	if timestamp, err := ptypes.Timestamp(entry.GetExpirationDate()); err == nil && expirationDate == "" {
+		expirationDate = timestamp.Local().Format("02/01/2006")
+	}
+
+	return []string{
+		strconv.FormatInt(entry.GetRecordId(), 10),
+		entry.GetCompanyNumber(),
+		entry.GetRegistrationNumber(),
+		name,
+		entry.GetAddress().GetCity(),
+		expirationDate,
+		strings.ToLower(strings.TrimPrefix(entry.GetValidityStatus().String(), "VALIDITY_STATUS_")),
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/united-drivers/go-revtc/revtc"
+	"strings"
+	"testing"
+)
+
+func TestCLIExitCodes(t *testing.T) {
+	client = newFixtureClient()
+
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"help"}, 0},
+		{[]string{"record", "-h"}, 0},
+		{[]string{"lookup", "--siren", "732829320"}, 0},
+		{[]string{"lookup", "--reg", "EVTC069180042", "--format", "csv"}, 0},
+		{[]string{"record", "1234"}, 0},
+		{[]string{"record", "--format", "json", "1234"}, 0},
+		{[]string{"record", "1234", "--format", "json"}, 0},
+		{[]string{"search", "--city", "Lyon", "--page", "2"}, 0},
+		// usage errors
+		{[]string{"frobnicate"}, 2},
+		{[]string{"lookup"}, 2},
+		{[]string{"lookup", "--siren", "732829320", "--reg", "EVTC069180042"}, 2},
+		{[]string{"lookup", "--siren", "732829320", "--format", "xml"}, 2},
+		{[]string{"lookup", "--vin", "1"}, 2},
+		{[]string{"record"}, 2},
+		{[]string{"record", "1234", "5678"}, 2},
+		{[]string{"record", "1234", "--format"}, 2},
+		{[]string{"record", "1234", "--format", "xml"}, 2},
+		{[]string{"search"}, 2},
+		// lookup errors
+		{[]string{"lookup", "--siren", "73282932"}, 1},
+		{[]string{"lookup", "--siren", "542065479"}, 1},
+		{[]string{"record", "abc"}, 1},
+		{[]string{"record", "0"}, 1},
+		{[]string{"search", "--city", "Lyon", "--page", "0"}, 1},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+
+		if code := runCLI(test.args, &stdout, &stderr); code != test.code {
+			t.Errorf("runCLI(%q) = %d, want %d (stderr %q)", test.args, code, test.code, stderr.String())
+		}
+
+		if test.code != 0 && stderr.Len() == 0 {
+			t.Errorf("runCLI(%q) failed without a message", test.args)
+		}
+	}
+}
+
+func TestCLIOutput(t *testing.T) {
+	client = newFixtureClient()
+
+	run := func(args ...string) (string, string) {
+		var stdout, stderr bytes.Buffer
+
+		if code := runCLI(args, &stdout, &stderr); code != 0 {
+			t.Fatalf("runCLI(%q) = %d: %s", args, code, stderr.String())
+		}
+
+		return stdout.String(), stderr.String()
+	}
+
+	t.Run("table", func(t *testing.T) {
+		stdout, stderr := run("search", "--city", "Lyon")
+		lines := strings.Split(stdout, "\n")
+		want := []string{
+			"RECORD_ID  COMPANY_NUMBER  REGISTRATION_NUMBER  NAME           CITY          EXPIRATION_DATE  VALIDITY",
+			"5678       443061841       EVTC069180042        Claire MARTIN  LYON          15/06/2020       expired",
+			"9012       552100554       EVTC069190007        Paul BERNARD   VILLEURBANNE  30/09/2031",
+		}
+
+		if len(lines) != 4 || lines[0] != want[0] || lines[1] != want[1] || !strings.HasPrefix(lines[2], want[2]) {
+			t.Errorf("table output:\n%s\nwant:\n%s", stdout, strings.Join(want, "\n"))
+		}
+
+		if hint := "3 operators match, run again with --page 2 for more\n"; stderr != hint {
+			t.Errorf("stderr = %q, want %q", stderr, hint)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		stdout, _ := run("record", "1234", "--format", "csv")
+		records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"1234", "732829320", "EVTC075150001", "TRANSPORTS DUPONT", "PARIS CEDEX 02", "01/03/2030"}
+
+		if len(records) != 2 || strings.Join(records[0], ",") != strings.Join(entryColumns, ",") {
+			t.Fatalf("csv output:\n%s", stdout)
+		}
+
+		// the validity column depends on the day the test runs
+		if got := strings.Join(records[1][:len(want)], ","); got != strings.Join(want, ",") {
+			t.Errorf("csv row = %s, want %s", got, strings.Join(want, ","))
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		stdout, stderr := run("search", "--city", "Lyon", "--format", "json")
+
+		var result revtc.SearchResult
+
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("json output: %v\n%s", err, stdout)
+		}
+
+		if len(result.Entries) != 2 || result.Entries[0].RegistrationNumber != "EVTC069180042" || result.TotalCount != 3 || !result.HasMore {
+			t.Errorf("json output:\n%s", stdout)
+		}
+
+		// the hint would break the output of scripts reading stdout and
+		// stderr together
+		if stderr != "" {
+			t.Errorf("stderr = %q, want nothing", stderr)
+		}
+	})
+}
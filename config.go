@@ -0,0 +1,202 @@
+package main
+
+import (
+	"github.com/united-drivers/go-revtc/revtc"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultCacheSize          = 10000
+	defaultHTTPPort           = "8080"
+	defaultHTTPReadTimeout    = 10 * time.Second
+	defaultHTTPWriteTimeout   = 90 * time.Second
+	defaultHTTPRequestTimeout = 60 * time.Second
+	defaultBatchMaxSize       = 5000
+	defaultBatchTimeout       = 2 * time.Hour
+	defaultParserDriftWindow  = time.Hour
+)
+
+func envString(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func envInt(name string, fallback int) int {
+	value := os.Getenv(name)
+
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+
+	if err != nil {
+		log.Fatalf("invalid %s: %v", name, err)
+	}
+
+	return number
+}
+
+func envFloat(name string, fallback float64) float64 {
+	value := os.Getenv(name)
+
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+
+	if err != nil {
+		log.Fatalf("invalid %s: %v", name, err)
+	}
+
+	return number
+}
+
+// envDuration reads a time.ParseDuration value such as "30s" from the
+// environment.
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+
+	if err != nil {
+		log.Fatalf("invalid %s: %v", name, err)
+	}
+
+	return duration
+}
+
+// batchConfig bounds POST /batch and the Lookup RPC streams.
+type batchConfig struct {
+	maxSize     int
+	concurrency int
+	timeout     time.Duration
+}
+
+// batchSettings holds the batch configuration, read by serve at startup so
+// that an invalid value stops the server before it handles requests.
+var batchSettings = batchConfig{
+	maxSize:     defaultBatchMaxSize,
+	concurrency: revtc.DefaultBatchConcurrency,
+	timeout:     defaultBatchTimeout,
+}
+
+// parserDriftWindow is how long GET /health reports layout drift, read by
+// serve at startup.
+var parserDriftWindow = defaultParserDriftWindow
+
+// batchOptions builds the batch configuration from the environment. Every
+// setting must be positive: zero would reject, stall or time out every batch.
+func batchOptions() batchConfig {
+	config := batchConfig{
+		maxSize:     envInt("BATCH_MAX_SIZE", defaultBatchMaxSize),
+		concurrency: envInt("BATCH_CONCURRENCY", revtc.DefaultBatchConcurrency),
+		timeout:     envDuration("BATCH_TIMEOUT", defaultBatchTimeout),
+	}
+
+	if config.maxSize < 1 {
+		log.Fatalf("invalid BATCH_MAX_SIZE: %d, must be positive", config.maxSize)
+	}
+
+	if config.concurrency < 1 {
+		log.Fatalf("invalid BATCH_CONCURRENCY: %d, must be positive", config.concurrency)
+	}
+
+	if config.timeout <= 0 {
+		log.Fatalf("invalid BATCH_TIMEOUT: %v, must be positive", config.timeout)
+	}
+
+	return config
+}
+
+// clientOptions builds the revtc.Client configuration from the environment.
+func clientOptions() []revtc.Option {
+	opts := []revtc.Option{
+		revtc.WithBaseURL(envString("UPSTREAM_BASE_URL", revtc.DefaultBaseURL)),
+		revtc.WithTimeout(envDuration("UPSTREAM_TIMEOUT", revtc.DefaultTimeout)),
+		revtc.WithRateLimit(
+			envFloat("UPSTREAM_RATE_LIMIT", revtc.DefaultRateLimit),
+			envInt("UPSTREAM_RATE_BURST", revtc.DefaultRateBurst),
+		),
+		revtc.WithMaxConcurrency(envInt("UPSTREAM_MAX_CONCURRENCY", revtc.DefaultMaxConcurrency)),
+		revtc.WithMaxWait(envDuration("UPSTREAM_MAX_WAIT", revtc.DefaultMaxWait)),
+		revtc.WithRetry(
+			envInt("UPSTREAM_MAX_ATTEMPTS", revtc.DefaultMaxAttempts),
+			envDuration("UPSTREAM_RETRY_DELAY", revtc.DefaultRetryDelay),
+			envDuration("UPSTREAM_RETRY_MAX_DELAY", revtc.DefaultRetryMaxDelay),
+		),
+		revtc.WithCircuitBreaker(
+			envInt("UPSTREAM_BREAKER_THRESHOLD", revtc.DefaultBreakerThreshold),
+			envDuration("UPSTREAM_BREAKER_COOLDOWN", revtc.DefaultBreakerCooldown),
+		),
+		revtc.WithExpiryWarning(envDuration("EXPIRY_WARNING", revtc.DefaultExpiryWarning)),
+	}
+
+	var cache revtc.Cache
+
+	cacheTTL := envDuration("CACHE_TTL", revtc.DefaultCacheTTL)
+	cacheStale := envDuration("CACHE_STALE", 0)
+
+	switch kind := os.Getenv("CACHE"); kind {
+	case "":
+	case "memory":
+		cache = revtc.NewLRUCache(envInt("CACHE_SIZE", defaultCacheSize))
+	case "file":
+		fileCache, err := revtc.NewFileCache(envString("CACHE_DIR", "cache"), cacheTTL+cacheStale)
+
+		if err != nil {
+			log.Fatalf("invalid CACHE_DIR: %v", err)
+		}
+
+		cache = fileCache
+	default:
+		log.Fatalf("invalid CACHE: %q, expected memory or file", kind)
+	}
+
+	if cache != nil {
+		opts = append(opts,
+			revtc.WithCache(cache, cacheTTL),
+			revtc.WithStaleWhileRevalidate(cacheStale),
+		)
+	}
+
+	// replay saved pages instead of reaching the registry, or save the
+	// pages it returns
+	if dir := os.Getenv("UPSTREAM_FIXTURES"); dir != "" {
+		opts = append(opts, revtc.WithFetcher(revtc.FixtureFetcher{Dir: dir}))
+	} else if dir := os.Getenv("UPSTREAM_RECORD"); dir != "" {
+		opts = append(opts, revtc.WithFetcher(revtc.RecordingFetcher{Fetcher: http.DefaultClient, Dir: dir}))
+	}
+
+	// keep the pages the parser does not recognise
+	if dir := os.Getenv("LAYOUT_SAMPLE_DIR"); dir != "" {
+		opts = append(opts, revtc.WithDriftLog(log.New(os.Stderr, "revtc: ", log.LstdFlags), dir))
+	}
+
+	return opts
+}
+
+// watchOptions builds the revtc.Watchlist configuration from the
+// environment.
+func watchOptions() []revtc.WatchOption {
+	return []revtc.WatchOption{
+		revtc.WithWatchInterval(
+			envDuration("WATCH_INTERVAL", revtc.DefaultWatchInterval),
+			envDuration("WATCH_JITTER", revtc.DefaultWatchJitter),
+		),
+		revtc.WithWatchRetry(envDuration("WATCH_RETRY_DELAY", revtc.DefaultWatchRetryDelay)),
+		revtc.WithWatchRateLimit(envFloat("WATCH_RATE_LIMIT", revtc.DefaultWatchRateLimit)),
+	}
+}
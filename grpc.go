@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"github.com/united-drivers/go-revtc/revtc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const defaultGRPCPort = "50051"
+
+// recordIdPrefix marks the Lookup inputs holding a registry record id.
+const recordIdPrefix = "record:"
+
+type reVTCServer struct {
+	client *revtc.Client
+}
+
+func (s *reVTCServer) GetBySIREN(ctx context.Context, in *pb.SimpleInput) (*pb.VTCEntry, error) {
+	result, err := s.client.GetByCompanyNumber(ctx, in.GetInput())
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &result, nil
+}
+
+// Lookup resolves a stream of lookups, answering each one as soon as it
+// completes. At most BATCH_CONCURRENCY lookups of a stream run at once, on
+// top of the client rate limit and concurrency cap.
+func (s *reVTCServer) Lookup(stream pb.ReVTC_LookupServer) error {
+	ctx := stream.Context()
+	concurrency := batchSettings.concurrency
+
+	if concurrency <= 0 {
+		concurrency = revtc.DefaultBatchConcurrency
+	}
+
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	var sendMu sync.Mutex
+	var sendErr error
+
+	send := func(response *pb.LookupResponse) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+
+		if sendErr == nil {
+			sendErr = stream.Send(response)
+		}
+	}
+
+	for {
+		request, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			wg.Wait()
+
+			return err
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+
+		go func(request *pb.LookupRequest) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			send(s.lookup(ctx, request))
+		}(request)
+	}
+
+	wg.Wait()
+
+	return sendErr
+}
+
+func (s *reVTCServer) lookup(ctx context.Context, request *pb.LookupRequest) *pb.LookupResponse {
+	response := &pb.LookupResponse{CorrelationId: request.GetCorrelationId()}
+	id, err := parseLookupInput(request.GetInput())
+
+	if err == nil {
+		var entry pb.VTCEntry
+
+		if entry, err = s.client.Lookup(ctx, id); err == nil {
+			response.Entry = &entry
+
+			return response
+		}
+	}
+
+	response.Error = &pb.LookupError{
+		Code:    int32(grpcCode(err)),
+		Message: err.Error(),
+	}
+
+	return response
+}
+
+// parseLookupInput reads a Lookup input: "record:" followed by a record id,
+// or an identifier parsed by revtc.ParseIdentifier.
+func parseLookupInput(input string) (revtc.Identifier, error) {
+	if !strings.HasPrefix(input, recordIdPrefix) {
+		return revtc.ParseIdentifier(input)
+	}
+
+	recordId, err := strconv.Atoi(strings.TrimPrefix(input, recordIdPrefix))
+
+	if err != nil || recordId < 1 {
+		return revtc.Identifier{}, &revtc.InvalidInputError{Field: "record id", Reason: "must be a positive integer"}
+	}
+
+	return revtc.Identifier{Kind: revtc.IdentifierRecordId, Value: strconv.Itoa(recordId)}, nil
+}
+
+// grpcCode returns the status code matching the kind of err.
+func grpcCode(err error) codes.Code {
+	code := codes.Internal
+
+	switch err.(type) {
+	case *revtc.InvalidInputError:
+		code = codes.InvalidArgument
+	case *revtc.UpstreamError:
+		code = codes.Unavailable
+	case *revtc.LayoutError:
+		code = codes.Internal
+	}
+
+	if err == revtc.ErrNotFound || err == revtc.ErrPageOutOfRange {
+		code = codes.NotFound
+	}
+
+	if err == revtc.ErrAmbiguous {
+		code = codes.FailedPrecondition
+	}
+
+	if err == revtc.ErrRateLimited {
+		code = codes.ResourceExhausted
+	}
+
+	if err == revtc.ErrRegistryUnavailable {
+		code = codes.Unavailable
+	}
+
+	if err == context.DeadlineExceeded {
+		code = codes.DeadlineExceeded
+	}
+
+	if err == context.Canceled {
+		code = codes.Canceled
+	}
+
+	return code
+}
+
+// grpcError converts err to a status error with the code matching its kind.
+func grpcError(err error) error {
+	return status.Error(grpcCode(err), err.Error())
+}
+
+func grpcAddress() string {
+	return ":" + envString("GRPC_PORT", defaultGRPCPort)
+}
+
+func serveGRPC() {
+	lis, err := net.Listen("tcp", grpcAddress())
+
+	if err != nil {
+		log.Fatalf("grpc: failed to listen: %v", err)
+	}
+
+	s := grpc.NewServer()
+	pb.RegisterReVTCServer(s, &reVTCServer{client: client})
+
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("grpc: failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"github.com/united-drivers/go-revtc/revtc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// newFixtureClient answers from the recorded pages of revtc/testdata.
+func newFixtureClient() *revtc.Client {
+	return revtc.NewClient(
+		revtc.WithFetcher(revtc.FixtureFetcher{Dir: "revtc/testdata"}),
+		revtc.WithRateLimit(0, 0),
+		revtc.WithMaxConcurrency(0),
+		revtc.WithRetry(1, 0, 0),
+		revtc.WithDriftLog(nil, ""),
+	)
+}
+
+// newTestGRPCClient serves the ReVTC service over an in-memory connection,
+// answering from the recorded pages of revtc/testdata.
+func newTestGRPCClient(t *testing.T) pb.ReVTCClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterReVTCServer(server, &reVTCServer{client: newFixtureClient()})
+
+	go server.Serve(lis)
+
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(), grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return lis.Dial()
+	}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	return pb.NewReVTCClient(conn)
+}
+
+func TestLookupStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := newTestGRPCClient(t).Lookup(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// correlation id to the registration number found, or the error code
+	tests := map[string]struct {
+		input              string
+		registrationNumber string
+		code               codes.Code
+	}{
+		"siren":        {"732829320", "EVTC075150001", codes.OK},
+		"registration": {"EVTC069180042", "EVTC069180042", codes.OK},
+		"record":       {"record:1234", "EVTC075150001", codes.OK},
+		"short siren":  {"73282932", "", codes.InvalidArgument},
+		"bare record":  {"1234", "", codes.InvalidArgument},
+		"layout":       {"542065479", "", codes.Internal},
+		"missing page": {"record:42", "", codes.Unavailable},
+	}
+
+	for id, test := range tests {
+		if err := stream.Send(&pb.LookupRequest{CorrelationId: id, Input: test.input}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[string]bool{}
+
+	for {
+		response, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("stream ended with %v after %d responses", err, len(seen))
+		}
+
+		id := response.GetCorrelationId()
+		test, ok := tests[id]
+
+		if !ok || seen[id] {
+			t.Errorf("unexpected response for correlation id %q", id)
+
+			continue
+		}
+
+		seen[id] = true
+
+		if test.code == codes.OK {
+			if response.GetError() != nil || response.GetEntry().GetRegistrationNumber() != test.registrationNumber {
+				t.Errorf("%s: got entry %v and error %v, want %s", id, response.GetEntry().GetRegistrationNumber(), response.GetError(), test.registrationNumber)
+			}
+		} else if response.GetEntry() != nil || codes.Code(response.GetError().GetCode()) != test.code {
+			t.Errorf("%s: got entry %v and error %v, want code %v", id, response.GetEntry().GetRegistrationNumber(), response.GetError(), test.code)
+		}
+	}
+
+	if len(seen) != len(tests) {
+		t.Errorf("got %d responses before the stream closed, want %d", len(seen), len(tests))
+	}
+}
+
+func TestParseLookupInput(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  revtc.IdentifierKind
+		valid bool
+	}{
+		{"732829320", revtc.IdentifierCompanyNumber, true},
+		{"EVTC075123456", revtc.IdentifierRegistrationNumber, true},
+		{"record:123456", revtc.IdentifierRecordId, true},
+		{"123456", 0, false},
+		{"record:", 0, false},
+		{"record:-1", 0, false},
+	}
+
+	for _, test := range tests {
+		id, err := parseLookupInput(test.input)
+
+		if test.valid != (err == nil) || test.valid && id.Kind != test.kind {
+			t.Errorf("parseLookupInput(%q) = %v, %v", test.input, id, err)
+		}
+	}
+}
@@ -1,282 +1,293 @@
 package main
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"github.com/andybalholm/cascadia"
 	"github.com/gin-gonic/gin"
-	google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
-	pb "github.com/united-drivers/go-revtc/proto"
-	"golang.org/x/net/html"
+	"github.com/united-drivers/go-revtc/revtc"
+	"log"
 	"net/http"
-	"net/url"
-	"strings"
+	"os"
+	"strconv"
 	"time"
 )
 
-const baseUrl = "https://registre-vtc.developpement-durable.gouv.fr/public"
-
-type APISearchParams int
-
-const (
-	lCompanyName         = "Dénomination"
-	lCompanyNumber       = "Numéro SIREN"
-	lRegistrationNumber  = "Numéro d'inscription"
-	lContactFirstName    = "Prénom"
-	lContactLastName     = "Nom"
-	lCity                = "Ville"
-	lAcronym             = "Sigle"
-	lExpirationDate      = "Valide jusqu'au"
-	lLegalEntityType     = "Statut"
-	lCompanyType         = "Forme juridique"
-	lBrand               = "Marque/Nom commercial"
-	lPostalCode          = "Code Postal"
-	lDepartment          = "Département"
-	lCountry             = "Pays"
-	lIndividualTitle     = "Civilité"
-	lIndividualFirstName = "Prénom principal"
-	lIndividualLastName  = "Nom d'usage"
-)
+// statusClientClosedRequest is the non-standard status logged for lookups
+// abandoned by their caller.
+const statusClientClosedRequest = 499
+
+var client *revtc.Client
+
+var searchQueryParams = map[string]revtc.APISearchParams{
+	"registration_number": revtc.SearchRegistrationNumber,
+	"company_number":      revtc.SearchCompanyNumber,
+	"person_name":         revtc.SearchPersonName,
+	"company_name":        revtc.SearchCompanyName,
+	"acronym":             revtc.SearchAcronym,
+	"brand":               revtc.SearchBrand,
+	"city":                revtc.SearchCity,
+	"postal_code":         revtc.SearchPostalCode,
+	"department":          revtc.SearchDepartment,
+}
 
-const (
-	sRegistrationNumber APISearchParams = iota
-	sCompanyNumber
-	sPersonName
-	sCompanyName
-	sAcronym
-	sBrand
-	sCity
-	sPostalCode
-	sDepartment
-)
+// errorKind returns the HTTP status and the error kind reported for err.
+func errorKind(err error) (int, string) {
+	status, kind := http.StatusInternalServerError, "internal"
+
+	switch err.(type) {
+	case *revtc.InvalidInputError:
+		status, kind = http.StatusBadRequest, "invalid_input"
+	case *revtc.UpstreamError:
+		status, kind = http.StatusBadGateway, "upstream"
+	case *revtc.LayoutError:
+		status, kind = http.StatusInternalServerError, "layout"
+	}
 
-var personTitleMapping = []string{
-	pb.PERSON_TITLE_PERSON_TITLE_MR:  "M.",
-	pb.PERSON_TITLE_PERSON_TITLE_MRS: "Mme",
-}
+	if err == revtc.ErrNotFound || err == revtc.ErrPageOutOfRange {
+		status, kind = http.StatusNotFound, "not_found"
+	}
+
+	if err == revtc.ErrAmbiguous {
+		status, kind = http.StatusConflict, "ambiguous"
+	}
+
+	if err == revtc.ErrRateLimited {
+		status, kind = http.StatusTooManyRequests, "rate_limited"
+	}
+
+	if err == revtc.ErrRegistryUnavailable {
+		status, kind = http.StatusServiceUnavailable, "unavailable"
+	}
+
+	if err == context.DeadlineExceeded {
+		status, kind = http.StatusGatewayTimeout, "timeout"
+	}
 
-var businessEntityTypeMapping = []string{
-	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SA:   "Société anonyme",
-	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SARL: "Société à responsabilité limitée",
-	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SAS:  "Société par actions simplifiée",
-	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SASU: "Société par actions simplifiée unipersonnelle",
-	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EURL: "Entreprise unipersonnelle à responsabilité limitée",
+	if err == context.Canceled {
+		status, kind = statusClientClosedRequest, "canceled"
+	}
+
+	return status, kind
 }
 
-var legalEntityTypeMapping = []string{
-	pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_COMPANY:    "Personne morale",
-	pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_INDIVIDUAL: "Personne physique",
+// httpError renders err with the HTTP status matching its kind.
+func httpError(c *gin.Context, err error) {
+	status, kind := errorKind(err)
+
+	c.JSON(status, gin.H{
+		"error":   kind,
+		"message": err.Error(),
+	})
 }
 
-func getKeyForMappingValue(mapping []string, inputValue string, defaultValue int) int {
-	for key, value := range mapping {
-		if value == inputValue {
-			return key
-		}
+func httpSimpleSearch(c *gin.Context, searchType revtc.APISearchParams) {
+	input := c.Param("input")
+	result, err := client.GetByAdvancedSearch(c.Request.Context(), map[revtc.APISearchParams]string{
+		searchType: input,
+	})
+
+	if err != nil {
+		httpError(c, err)
+
+		return
 	}
 
-	return defaultValue
+	c.JSON(http.StatusOK, result)
 }
 
-func castAPIPersonTitle(str string) pb.PERSON_TITLE {
-	return pb.PERSON_TITLE(getKeyForMappingValue(personTitleMapping, str, int(pb.PERSON_TITLE_PERSON_TITLE_OTHER)))
+func httpSearchByRegNumber(c *gin.Context) {
+	httpSimpleSearch(c, revtc.SearchRegistrationNumber)
 }
 
-func castAPIBusinessEntityType(str string) pb.BUSINESS_ENTITY_TYPE {
-	return pb.BUSINESS_ENTITY_TYPE(getKeyForMappingValue(businessEntityTypeMapping, str, int(pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER)))
+func httpSearchByCompanyNumber(c *gin.Context) {
+	httpSimpleSearch(c, revtc.SearchCompanyNumber)
 }
 
-func castAPILegalEntityType(str string) pb.LEGAL_ENTITY_TYPE {
-	return pb.LEGAL_ENTITY_TYPE(getKeyForMappingValue(legalEntityTypeMapping, str, int(pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_OTHER)))
-}
+func httpGetByRecordId(c *gin.Context) {
+	recordId, err := strconv.Atoi(c.Param("id"))
 
-func mapDictToObject(mapped map[string]string) pb.VTCEntry {
-	var result = pb.VTCEntry{
-		CompanyNumber:      mapped[lCompanyNumber],
-		RegistrationNumber: mapped[lRegistrationNumber],
-		LegalEntityType:    castAPILegalEntityType(mapped[lLegalEntityType]),
-	}
+	if err != nil || recordId < 1 {
+		httpError(c, &revtc.InvalidInputError{Field: "record id", Reason: "must be a positive integer"})
 
-	result.Address = &pb.Address{
-		City:       mapped[lCity],
-		Country:    mapped[lCountry],
-		PostalCode: mapped[lPostalCode],
-		Department: mapped[lDepartment],
+		return
 	}
 
-	if result.LegalEntityType == pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_COMPANY {
-		result.Company = &pb.Company{
-			Name:    mapped[lCompanyName],
-			Acronym: mapped[lAcronym],
-			Contact: &pb.PersonName{
-				FirstName: mapped[lContactFirstName],
-				LastName:  mapped[lContactLastName],
-			},
-			CompanyType: castAPIBusinessEntityType(mapped[lCompanyType]),
-			Brand:       mapped[lBrand],
-		}
+	result, err := client.GetByRecordId(c.Request.Context(), recordId)
 
-	} else if result.LegalEntityType == pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_INDIVIDUAL {
-		result.Individual = &pb.Individual{
-			Title: castAPIPersonTitle(mapped[lIndividualTitle]),
-			Name: &pb.PersonName{
-				FirstName: mapped[lIndividualFirstName],
-				LastName:  mapped[lIndividualLastName],
-			},
-		}
-	}
+	if err != nil {
+		httpError(c, err)
 
-	expirationDate, _ := time.Parse("02/01/2006", mapped[lExpirationDate])
-	result.ExpirationDate = &google_protobuf.Timestamp{
-		Seconds: int64(expirationDate.Second()),
-		Nanos:   int32(expirationDate.Nanosecond()),
+		return
 	}
 
-	return result
+	c.JSON(http.StatusOK, result)
 }
 
-func handleSingleResultPage(res *http.Response) (pb.VTCEntry, error) {
-	if res.StatusCode != 200 {
-		return pb.VTCEntry{}, errors.New("not found")
-	}
+func httpSearch(c *gin.Context) {
+	params := map[revtc.APISearchParams]string{}
+	page := 0
 
-	doc, errHtml := html.Parse(res.Body)
-	sel, errCss := cascadia.Compile(".cLabel")
+	for key, values := range c.Request.URL.Query() {
+		if key == "page" {
+			var err error
 
-	if errHtml != nil {
-		return pb.VTCEntry{}, errHtml
-	}
+			if page, err = strconv.Atoi(values[0]); err != nil || page < 1 {
+				httpError(c, &revtc.InvalidInputError{Field: "page", Reason: "must be a positive integer"})
 
-	if errCss != nil {
-		return pb.VTCEntry{}, errCss
-	}
+				return
+			}
 
-	mapped := map[string]string{}
+			continue
+		}
 
-	results := sel.MatchAll(doc)
+		param, ok := searchQueryParams[key]
 
-	for _, node := range results {
-		parent := node.Parent
+		if !ok {
+			httpError(c, &revtc.InvalidInputError{Field: "search criterion", Reason: fmt.Sprintf("unknown criterion %q", key)})
 
-		// edge case, in one of the tables labels are wrapped in a <span> elt
-		if parent.Data == "span" {
-			parent = parent.Parent
+			return
 		}
 
-		value := getTextToken(parent)
+		params[param] = values[0]
+	}
+
+	if err := revtc.ValidateSearchParams(params); err != nil {
+		httpError(c, err)
 
-		mapped[getTextToken(node)] = value
+		return
 	}
 
-	if mapped[lCompanyNumber] == "" {
-		return pb.VTCEntry{}, errors.New("not found")
+	// a city-wide search spans hundreds of registry pages, way past the
+	// request timeout; callers follow has_more instead
+	if page == 0 {
+		page = 1
 	}
 
-	return mapDictToObject(mapped), nil
+	result, err := client.SearchPage(c.Request.Context(), params, page)
+
+	if err != nil {
+		httpError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
 }
 
-func getTextToken(node *html.Node) string {
-	subNode := node.FirstChild
+func httpHealth(c *gin.Context) {
+	breaker := client.BreakerStatus()
+	parser := client.ParserHealth()
+	status := "ok"
 
-	for subNode != nil {
-		if subNode.Type == html.TextNode {
-			value := strings.TrimSpace(subNode.Data)
+	if breaker.State != revtc.BreakerClosed {
+		status = "degraded"
+	}
 
-			if value != "" {
-				return value
-			}
-		}
+	// the registry layout changed recently, "not found" answers may be wrong
+	driftRecent := !parser.LastDriftAt.IsZero() && time.Since(parser.LastDriftAt) < parserDriftWindow
 
-		subNode = subNode.NextSibling
+	if driftRecent {
+		status = "degraded"
 	}
 
-	return ""
-}
+	body := gin.H{
+		"status": status,
+		"circuit_breaker": gin.H{
+			"state":                breaker.State.String(),
+			"consecutive_failures": breaker.ConsecutiveFailures,
+		},
+	}
 
-func GetByRecordId(recordId int) (pb.VTCEntry, error) {
-	var requestUrl = fmt.Sprintf(
-		"%s/rechercheExploitant.exploitantDetails.action?dossier.id=%d",
-		baseUrl, recordId)
+	if !breaker.OpenedAt.IsZero() {
+		body["circuit_breaker"].(gin.H)["opened_at"] = breaker.OpenedAt
+	}
 
-	resp, err := http.Get(requestUrl)
+	body["parser"] = gin.H{
+		"layout_drift":       driftRecent,
+		"drift_pages":        parser.DriftPages,
+		"unrecognised_pages": parser.UnrecognisedPages,
+	}
 
-	if err != nil {
-		return pb.VTCEntry{}, err
+	if !parser.LastDriftAt.IsZero() {
+		body["parser"].(gin.H)["last_drift_at"] = parser.LastDriftAt
 	}
 
-	return handleSingleResultPage(resp)
+	c.JSON(http.StatusOK, body)
 }
 
-func GetByAdvancedSearch(params map[APISearchParams]string) (pb.VTCEntry, error) {
-	var requestUrl = fmt.Sprintf(
-		"%s/rechercheExploitant.avancee.action", baseUrl)
-
-	resp, err := http.PostForm(requestUrl, url.Values{
-		"rechercheCriteres.numeroInscription":              {params[sRegistrationNumber]},
-		"rechercheCriteres.nomRepresentantLegal":           {params[sPersonName]},
-		"rechercheCriteres.nomDenomination":                {params[sCompanyName]},
-		"rechercheCriteres.numeroSiren":                    {params[sCompanyNumber]},
-		"rechercheCriteres.sigle":                          {params[sAcronym]},
-		"rechercheCriteres.marque":                         {params[sBrand]},
-		"rechercheCriteres.autreFormeJuridique":            {""},
-		"rechercheCriteres.idFormeJuridique":               {""},
-		"rechercheCriteres.ville":                          {params[sCity]},
-		"rechercheCriteres.idPays":                         {""},
-		"rechercheCriteres.codePostal":                     {params[sPostalCode]},
-		"rechercheCriteres.idRegion":                       {""},
-		"rechercheCriteres.idDepartement":                  {params[sDepartment]},
-		"action:/public/rechercheExploitant.liste.avancee": {"Rechercher"},
-	})
+// requestTimeout bounds the context handed to lookups so that a slow
+// registry cannot hold handler goroutines forever.
+func requestTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
 
-	if err != nil {
-		return pb.VTCEntry{}, err
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
 	}
-
-	return handleSingleResultPage(resp)
 }
 
-func GetByCompanyNumber(companyNumber string) (pb.VTCEntry, error) {
-	return GetByAdvancedSearch(map[APISearchParams]string{
-		sCompanyNumber: companyNumber,
-	})
-}
+func main() {
+	if len(os.Args) > 1 && os.Args[1] != "serve" {
+		client = revtc.NewClient(clientOptions()...)
+		os.Exit(runCLI(os.Args[1:], os.Stdout, os.Stderr))
+	}
 
-func GetByRegistrationNumber(registrationNumber string) (pb.VTCEntry, error) {
-	return GetByAdvancedSearch(map[APISearchParams]string{
-		sRegistrationNumber: registrationNumber,
-	})
+	serve()
 }
 
-func httpSimpleSearch(c *gin.Context, searchType APISearchParams) {
-	input := c.Param("input")
-	result, err := GetByAdvancedSearch(map[APISearchParams]string{
-		searchType: input,
-	})
+// newRouter registers the HTTP routes; the admin ones are only served when
+// adminToken is set.
+func newRouter(adminToken string) *gin.Engine {
+	r := gin.Default()
 
-	if err != nil {
-		c.JSON(400, gin.H{
-			"message": string(err.Error()),
-		})
+	r.GET("/health", httpHealth)
+	r.GET("/metrics", httpMetrics)
 
-		return
+	lookups := r.Group("/", requestTimeout(envDuration("HTTP_REQUEST_TIMEOUT", defaultHTTPRequestTimeout)))
+	lookups.GET("/registration_number/:input", httpSearchByRegNumber)
+	lookups.GET("/company_number/:input", httpSearchByCompanyNumber)
+	lookups.GET("/record/:id", httpGetByRecordId)
+	lookups.GET("/search", httpSearch)
+
+	r.GET("/watchlist", httpListWatchlist)
+	r.GET("/watchlist/events", httpWatchlistEvents)
+
+	if adminToken != "" {
+		// watching operators is persisted and queries the registry
+		r.PUT("/watchlist/:identifier", adminAuth(adminToken), httpAddToWatchlist)
+		r.DELETE("/watchlist/:identifier", adminAuth(adminToken), httpRemoveFromWatchlist)
+
+		admin := r.Group("/admin", adminAuth(adminToken))
+		admin.GET("/webhooks/dead_letters", httpListDeadLetters)
+		admin.POST("/webhooks/replay", httpReplayDeadLetters)
+		admin.POST("/webhooks/dead_letters/:id/replay", httpReplayDeadLetters)
 	}
 
-	c.JSON(http.StatusOK, result)
-}
+	r.POST("/batch", requestTimeout(batchSettings.timeout), httpBatch)
 
-func httpSearchByRegNumber(c *gin.Context) {
-	httpSimpleSearch(c, sRegistrationNumber)
+	return r
 }
 
-func httpSearchByCompanyNumber(c *gin.Context) {
-	httpSimpleSearch(c, sCompanyNumber)
-}
+// serve runs the HTTP server, and the gRPC server next to it.
+func serve() {
+	client = revtc.NewClient(clientOptions()...)
+	batchSettings = batchOptions()
+	parserDriftWindow = envDuration("PARSER_DRIFT_WINDOW", defaultParserDriftWindow)
 
-func main() {
-	r := gin.Default()
+	startWatchlist()
+	startWebhooks()
+
+	go serveGRPC()
 
-	r.GET("/registration_number/:input", httpSearchByRegNumber)
-	r.GET("/company_number/:input", httpSearchByCompanyNumber)
+	r := newRouter(os.Getenv("ADMIN_TOKEN"))
+
+	server := &http.Server{
+		Addr:         ":" + envString("PORT", defaultHTTPPort),
+		Handler:      batchWriteDeadline(r, batchSettings.timeout),
+		ReadTimeout:  envDuration("HTTP_READ_TIMEOUT", defaultHTTPReadTimeout),
+		WriteTimeout: envDuration("HTTP_WRITE_TIMEOUT", defaultHTTPWriteTimeout),
+	}
 
-	r.Run() // listen and serve on 0.0.0.0:8080
+	log.Fatal(server.ListenAndServe())
 }
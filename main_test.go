@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"github.com/united-drivers/go-revtc/revtc"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"testing"
+)
+
+func TestErrorKinds(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		kind   string
+		code   codes.Code
+	}{
+		{&revtc.InvalidInputError{Field: "SIREN", Reason: "must have 9 digits"}, http.StatusBadRequest, "invalid_input", codes.InvalidArgument},
+		{revtc.ErrNotFound, http.StatusNotFound, "not_found", codes.NotFound},
+		{revtc.ErrPageOutOfRange, http.StatusNotFound, "not_found", codes.NotFound},
+		{revtc.ErrAmbiguous, http.StatusConflict, "ambiguous", codes.FailedPrecondition},
+		{revtc.ErrRateLimited, http.StatusTooManyRequests, "rate_limited", codes.ResourceExhausted},
+		{&revtc.UpstreamError{StatusCode: http.StatusBadGateway}, http.StatusBadGateway, "upstream", codes.Unavailable},
+		{revtc.ErrRegistryUnavailable, http.StatusServiceUnavailable, "unavailable", codes.Unavailable},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout, "timeout", codes.DeadlineExceeded},
+		{context.Canceled, statusClientClosedRequest, "canceled", codes.Canceled},
+		{&revtc.LayoutError{Reason: "no detail table"}, http.StatusInternalServerError, "layout", codes.Internal},
+	}
+
+	for _, test := range tests {
+		if status, kind := errorKind(test.err); status != test.status || kind != test.kind {
+			t.Errorf("errorKind(%v) = %d, %q, want %d, %q", test.err, status, kind, test.status, test.kind)
+		}
+
+		if code := grpcCode(test.err); code != test.code {
+			t.Errorf("grpcCode(%v) = %v, want %v", test.err, code, test.code)
+		}
+	}
+}
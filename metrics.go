@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// writeCounter writes a counter in the Prometheus text format, one sample
+// per label value.
+func writeCounter(w io.Writer, name string, help string, label string, values map[string]int64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if label == "" {
+			fmt.Fprintf(w, "%s %d\n", name, values[key])
+		} else {
+			fmt.Fprintf(w, "%s{%s=%s} %d\n", name, label, strconv.Quote(key), values[key])
+		}
+	}
+}
+
+// httpMetrics exposes the parser health counters to Prometheus.
+func httpMetrics(c *gin.Context) {
+	parser := client.ParserHealth()
+
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+
+	writeCounter(w, "revtc_parser_pages_total", "Registry pages parsed, by kind.", "kind", map[string]int64{
+		"detail":       parser.DetailPages,
+		"result_list":  parser.ResultListPages,
+		"no_result":    parser.NoResultPages,
+		"unrecognised": parser.UnrecognisedPages,
+	})
+	writeCounter(w, "revtc_parser_drift_pages_total", "Registry pages whose layout differs from the one expected.", "", map[string]int64{
+		"": parser.DriftPages,
+	})
+	writeCounter(w, "revtc_parser_unknown_labels_total", "Labels of detail pages the parser does not know.", "label", parser.UnknownLabels)
+	writeCounter(w, "revtc_parser_missing_labels_total", "Expected labels missing from detail pages.", "label", parser.MissingLabels)
+
+	var lastDrift int64
+
+	if !parser.LastDriftAt.IsZero() {
+		lastDrift = parser.LastDriftAt.Unix()
+	}
+
+	fmt.Fprintf(w, "# HELP revtc_parser_last_drift_timestamp_seconds Time layout drift was last seen, 0 if never.\n")
+	fmt.Fprintf(w, "# TYPE revtc_parser_last_drift_timestamp_seconds gauge\n")
+	fmt.Fprintf(w, "revtc_parser_last_drift_timestamp_seconds %d\n", lastDrift)
+}
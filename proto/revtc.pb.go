@@ -12,8 +12,13 @@ It has these top-level messages:
 	PersonName
 	Individual
 	Company
+	Vehicles
+	Insurance
 	VTCEntry
 	SimpleInput
+	LookupRequest
+	LookupError
+	LookupResponse
 */
 package proto
 
@@ -89,29 +94,125 @@ func (LEGAL_ENTITY_TYPE) EnumDescriptor() ([]byte, []int) { return fileDescripto
 type BUSINESS_ENTITY_TYPE int32
 
 const (
-	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER BUSINESS_ENTITY_TYPE = 0
-	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SA    BUSINESS_ENTITY_TYPE = 1
-	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SARL  BUSINESS_ENTITY_TYPE = 2
-	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SAS   BUSINESS_ENTITY_TYPE = 3
-	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SASU  BUSINESS_ENTITY_TYPE = 4
-	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EURL  BUSINESS_ENTITY_TYPE = 5
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER              BUSINESS_ENTITY_TYPE = 0
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SA                 BUSINESS_ENTITY_TYPE = 1
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SARL               BUSINESS_ENTITY_TYPE = 2
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SAS                BUSINESS_ENTITY_TYPE = 3
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SASU               BUSINESS_ENTITY_TYPE = 4
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EURL               BUSINESS_ENTITY_TYPE = 5
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EI                 BUSINESS_ENTITY_TYPE = 6
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR BUSINESS_ENTITY_TYPE = 7
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EIRL               BUSINESS_ENTITY_TYPE = 8
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SNC                BUSINESS_ENTITY_TYPE = 9
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCS                BUSINESS_ENTITY_TYPE = 10
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCA                BUSINESS_ENTITY_TYPE = 11
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCOP               BUSINESS_ENTITY_TYPE = 12
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCIC               BUSINESS_ENTITY_TYPE = 13
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_COOPERATIVE        BUSINESS_ENTITY_TYPE = 14
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELARL             BUSINESS_ENTITY_TYPE = 15
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELAS              BUSINESS_ENTITY_TYPE = 16
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELAFA             BUSINESS_ENTITY_TYPE = 17
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCI                BUSINESS_ENTITY_TYPE = 18
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCP                BUSINESS_ENTITY_TYPE = 19
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SEM                BUSINESS_ENTITY_TYPE = 20
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GIE                BUSINESS_ENTITY_TYPE = 21
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_ASSOCIATION        BUSINESS_ENTITY_TYPE = 22
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_PUBLIC_ENTITY      BUSINESS_ENTITY_TYPE = 23
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SOCIETE_CIVILE     BUSINESS_ENTITY_TYPE = 24
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCM                BUSINESS_ENTITY_TYPE = 25
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GAEC               BUSINESS_ENTITY_TYPE = 26
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EARL               BUSINESS_ENTITY_TYPE = 27
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCEA               BUSINESS_ENTITY_TYPE = 28
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SE                 BUSINESS_ENTITY_TYPE = 29
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELCA              BUSINESS_ENTITY_TYPE = 30
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SEP                BUSINESS_ENTITY_TYPE = 31
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_INDIVISION         BUSINESS_ENTITY_TYPE = 32
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_FOREIGN_COMPANY    BUSINESS_ENTITY_TYPE = 33
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GIP                BUSINESS_ENTITY_TYPE = 34
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_FOUNDATION         BUSINESS_ENTITY_TYPE = 35
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MUTUAL             BUSINESS_ENTITY_TYPE = 36
+	BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_PROFESSIONAL_BODY  BUSINESS_ENTITY_TYPE = 37
 )
 
 var BUSINESS_ENTITY_TYPE_name = map[int32]string{
-	0: "BUSINESS_ENTITY_TYPE_OTHER",
-	1: "BUSINESS_ENTITY_TYPE_SA",
-	2: "BUSINESS_ENTITY_TYPE_SARL",
-	3: "BUSINESS_ENTITY_TYPE_SAS",
-	4: "BUSINESS_ENTITY_TYPE_SASU",
-	5: "BUSINESS_ENTITY_TYPE_EURL",
+	0:  "BUSINESS_ENTITY_TYPE_OTHER",
+	1:  "BUSINESS_ENTITY_TYPE_SA",
+	2:  "BUSINESS_ENTITY_TYPE_SARL",
+	3:  "BUSINESS_ENTITY_TYPE_SAS",
+	4:  "BUSINESS_ENTITY_TYPE_SASU",
+	5:  "BUSINESS_ENTITY_TYPE_EURL",
+	6:  "BUSINESS_ENTITY_TYPE_EI",
+	7:  "BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR",
+	8:  "BUSINESS_ENTITY_TYPE_EIRL",
+	9:  "BUSINESS_ENTITY_TYPE_SNC",
+	10: "BUSINESS_ENTITY_TYPE_SCS",
+	11: "BUSINESS_ENTITY_TYPE_SCA",
+	12: "BUSINESS_ENTITY_TYPE_SCOP",
+	13: "BUSINESS_ENTITY_TYPE_SCIC",
+	14: "BUSINESS_ENTITY_TYPE_COOPERATIVE",
+	15: "BUSINESS_ENTITY_TYPE_SELARL",
+	16: "BUSINESS_ENTITY_TYPE_SELAS",
+	17: "BUSINESS_ENTITY_TYPE_SELAFA",
+	18: "BUSINESS_ENTITY_TYPE_SCI",
+	19: "BUSINESS_ENTITY_TYPE_SCP",
+	20: "BUSINESS_ENTITY_TYPE_SEM",
+	21: "BUSINESS_ENTITY_TYPE_GIE",
+	22: "BUSINESS_ENTITY_TYPE_ASSOCIATION",
+	23: "BUSINESS_ENTITY_TYPE_PUBLIC_ENTITY",
+	24: "BUSINESS_ENTITY_TYPE_SOCIETE_CIVILE",
+	25: "BUSINESS_ENTITY_TYPE_SCM",
+	26: "BUSINESS_ENTITY_TYPE_GAEC",
+	27: "BUSINESS_ENTITY_TYPE_EARL",
+	28: "BUSINESS_ENTITY_TYPE_SCEA",
+	29: "BUSINESS_ENTITY_TYPE_SE",
+	30: "BUSINESS_ENTITY_TYPE_SELCA",
+	31: "BUSINESS_ENTITY_TYPE_SEP",
+	32: "BUSINESS_ENTITY_TYPE_INDIVISION",
+	33: "BUSINESS_ENTITY_TYPE_FOREIGN_COMPANY",
+	34: "BUSINESS_ENTITY_TYPE_GIP",
+	35: "BUSINESS_ENTITY_TYPE_FOUNDATION",
+	36: "BUSINESS_ENTITY_TYPE_MUTUAL",
+	37: "BUSINESS_ENTITY_TYPE_PROFESSIONAL_BODY",
 }
 var BUSINESS_ENTITY_TYPE_value = map[string]int32{
-	"BUSINESS_ENTITY_TYPE_OTHER": 0,
-	"BUSINESS_ENTITY_TYPE_SA":    1,
-	"BUSINESS_ENTITY_TYPE_SARL":  2,
-	"BUSINESS_ENTITY_TYPE_SAS":   3,
-	"BUSINESS_ENTITY_TYPE_SASU":  4,
-	"BUSINESS_ENTITY_TYPE_EURL":  5,
+	"BUSINESS_ENTITY_TYPE_OTHER":              0,
+	"BUSINESS_ENTITY_TYPE_SA":                 1,
+	"BUSINESS_ENTITY_TYPE_SARL":               2,
+	"BUSINESS_ENTITY_TYPE_SAS":                3,
+	"BUSINESS_ENTITY_TYPE_SASU":               4,
+	"BUSINESS_ENTITY_TYPE_EURL":               5,
+	"BUSINESS_ENTITY_TYPE_EI":                 6,
+	"BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR": 7,
+	"BUSINESS_ENTITY_TYPE_EIRL":               8,
+	"BUSINESS_ENTITY_TYPE_SNC":                9,
+	"BUSINESS_ENTITY_TYPE_SCS":                10,
+	"BUSINESS_ENTITY_TYPE_SCA":                11,
+	"BUSINESS_ENTITY_TYPE_SCOP":               12,
+	"BUSINESS_ENTITY_TYPE_SCIC":               13,
+	"BUSINESS_ENTITY_TYPE_COOPERATIVE":        14,
+	"BUSINESS_ENTITY_TYPE_SELARL":             15,
+	"BUSINESS_ENTITY_TYPE_SELAS":              16,
+	"BUSINESS_ENTITY_TYPE_SELAFA":             17,
+	"BUSINESS_ENTITY_TYPE_SCI":                18,
+	"BUSINESS_ENTITY_TYPE_SCP":                19,
+	"BUSINESS_ENTITY_TYPE_SEM":                20,
+	"BUSINESS_ENTITY_TYPE_GIE":                21,
+	"BUSINESS_ENTITY_TYPE_ASSOCIATION":        22,
+	"BUSINESS_ENTITY_TYPE_PUBLIC_ENTITY":      23,
+	"BUSINESS_ENTITY_TYPE_SOCIETE_CIVILE":     24,
+	"BUSINESS_ENTITY_TYPE_SCM":                25,
+	"BUSINESS_ENTITY_TYPE_GAEC":               26,
+	"BUSINESS_ENTITY_TYPE_EARL":               27,
+	"BUSINESS_ENTITY_TYPE_SCEA":               28,
+	"BUSINESS_ENTITY_TYPE_SE":                 29,
+	"BUSINESS_ENTITY_TYPE_SELCA":              30,
+	"BUSINESS_ENTITY_TYPE_SEP":                31,
+	"BUSINESS_ENTITY_TYPE_INDIVISION":         32,
+	"BUSINESS_ENTITY_TYPE_FOREIGN_COMPANY":    33,
+	"BUSINESS_ENTITY_TYPE_GIP":                34,
+	"BUSINESS_ENTITY_TYPE_FOUNDATION":         35,
+	"BUSINESS_ENTITY_TYPE_MUTUAL":             36,
+	"BUSINESS_ENTITY_TYPE_PROFESSIONAL_BODY":  37,
 }
 
 func (x BUSINESS_ENTITY_TYPE) String() string {
@@ -119,11 +220,45 @@ func (x BUSINESS_ENTITY_TYPE) String() string {
 }
 func (BUSINESS_ENTITY_TYPE) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
+type VALIDITY_STATUS int32
+
+const (
+	VALIDITY_STATUS_VALIDITY_STATUS_UNKNOWN  VALIDITY_STATUS = 0
+	VALIDITY_STATUS_VALIDITY_STATUS_VALID    VALIDITY_STATUS = 1
+	VALIDITY_STATUS_VALIDITY_STATUS_EXPIRING VALIDITY_STATUS = 2
+	VALIDITY_STATUS_VALIDITY_STATUS_EXPIRED  VALIDITY_STATUS = 3
+)
+
+var VALIDITY_STATUS_name = map[int32]string{
+	0: "VALIDITY_STATUS_UNKNOWN",
+	1: "VALIDITY_STATUS_VALID",
+	2: "VALIDITY_STATUS_EXPIRING",
+	3: "VALIDITY_STATUS_EXPIRED",
+}
+var VALIDITY_STATUS_value = map[string]int32{
+	"VALIDITY_STATUS_UNKNOWN":  0,
+	"VALIDITY_STATUS_VALID":    1,
+	"VALIDITY_STATUS_EXPIRING": 2,
+	"VALIDITY_STATUS_EXPIRED":  3,
+}
+
+func (x VALIDITY_STATUS) String() string {
+	return proto.EnumName(VALIDITY_STATUS_name, int32(x))
+}
+func (VALIDITY_STATUS) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
 type Address struct {
-	PostalCode string `protobuf:"bytes,1,opt,name=postal_code,json=postalCode" json:"postal_code,omitempty"`
-	City       string `protobuf:"bytes,2,opt,name=city" json:"city,omitempty"`
-	Country    string `protobuf:"bytes,3,opt,name=country" json:"country,omitempty"`
-	Department string `protobuf:"bytes,4,opt,name=department" json:"department,omitempty"`
+	PostalCode      string   `protobuf:"bytes,1,opt,name=postal_code,json=postalCode" json:"postal_code,omitempty"`
+	City            string   `protobuf:"bytes,2,opt,name=city" json:"city,omitempty"`
+	Country         string   `protobuf:"bytes,3,opt,name=country" json:"country,omitempty"`
+	Department      string   `protobuf:"bytes,4,opt,name=department" json:"department,omitempty"`
+	Lines           []string `protobuf:"bytes,5,rep,name=lines" json:"lines,omitempty"`
+	PostalCodeValid bool     `protobuf:"varint,6,opt,name=postal_code_valid,json=postalCodeValid" json:"postal_code_valid,omitempty"`
+	DepartmentCode  string   `protobuf:"bytes,7,opt,name=department_code,json=departmentCode" json:"department_code,omitempty"`
+	DepartmentName  string   `protobuf:"bytes,8,opt,name=department_name,json=departmentName" json:"department_name,omitempty"`
+	Region          string   `protobuf:"bytes,9,opt,name=region" json:"region,omitempty"`
+	CityNormalized  string   `protobuf:"bytes,10,opt,name=city_normalized,json=cityNormalized" json:"city_normalized,omitempty"`
+	CountryCode     string   `protobuf:"bytes,11,opt,name=country_code,json=countryCode" json:"country_code,omitempty"`
 }
 
 func (m *Address) Reset()                    { *m = Address{} }
@@ -159,6 +294,55 @@ func (m *Address) GetDepartment() string {
 	return ""
 }
 
+func (m *Address) GetLines() []string {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *Address) GetPostalCodeValid() bool {
+	if m != nil {
+		return m.PostalCodeValid
+	}
+	return false
+}
+
+func (m *Address) GetDepartmentCode() string {
+	if m != nil {
+		return m.DepartmentCode
+	}
+	return ""
+}
+
+func (m *Address) GetDepartmentName() string {
+	if m != nil {
+		return m.DepartmentName
+	}
+	return ""
+}
+
+func (m *Address) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *Address) GetCityNormalized() string {
+	if m != nil {
+		return m.CityNormalized
+	}
+	return ""
+}
+
+func (m *Address) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
+}
+
 type PersonName struct {
 	LastName  string `protobuf:"bytes,1,opt,name=last_name,json=lastName" json:"last_name,omitempty"`
 	FirstName string `protobuf:"bytes,2,opt,name=first_name,json=firstName" json:"first_name,omitempty"`
@@ -184,8 +368,10 @@ func (m *PersonName) GetFirstName() string {
 }
 
 type Individual struct {
-	Title PERSON_TITLE `protobuf:"varint,1,opt,name=title,enum=revtc.PERSON_TITLE" json:"title,omitempty"`
-	Name  *PersonName  `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Title          PERSON_TITLE         `protobuf:"varint,1,opt,name=title,enum=revtc.PERSON_TITLE" json:"title,omitempty"`
+	Name           *PersonName          `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	CompanyType    BUSINESS_ENTITY_TYPE `protobuf:"varint,3,opt,name=company_type,json=companyType,enum=revtc.BUSINESS_ENTITY_TYPE" json:"company_type,omitempty"`
+	CompanyTypeRaw string               `protobuf:"bytes,4,opt,name=company_type_raw,json=companyTypeRaw" json:"company_type_raw,omitempty"`
 }
 
 func (m *Individual) Reset()                    { *m = Individual{} }
@@ -207,12 +393,27 @@ func (m *Individual) GetName() *PersonName {
 	return nil
 }
 
+func (m *Individual) GetCompanyType() BUSINESS_ENTITY_TYPE {
+	if m != nil {
+		return m.CompanyType
+	}
+	return BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER
+}
+
+func (m *Individual) GetCompanyTypeRaw() string {
+	if m != nil {
+		return m.CompanyTypeRaw
+	}
+	return ""
+}
+
 type Company struct {
-	Name        string               `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Acronym     string               `protobuf:"bytes,2,opt,name=acronym" json:"acronym,omitempty"`
-	Brand       string               `protobuf:"bytes,3,opt,name=brand" json:"brand,omitempty"`
-	Contact     *PersonName          `protobuf:"bytes,4,opt,name=contact" json:"contact,omitempty"`
-	CompanyType BUSINESS_ENTITY_TYPE `protobuf:"varint,5,opt,name=company_type,json=companyType,enum=revtc.BUSINESS_ENTITY_TYPE" json:"company_type,omitempty"`
+	Name           string               `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Acronym        string               `protobuf:"bytes,2,opt,name=acronym" json:"acronym,omitempty"`
+	Brand          string               `protobuf:"bytes,3,opt,name=brand" json:"brand,omitempty"`
+	Contact        *PersonName          `protobuf:"bytes,4,opt,name=contact" json:"contact,omitempty"`
+	CompanyType    BUSINESS_ENTITY_TYPE `protobuf:"varint,5,opt,name=company_type,json=companyType,enum=revtc.BUSINESS_ENTITY_TYPE" json:"company_type,omitempty"`
+	CompanyTypeRaw string               `protobuf:"bytes,6,opt,name=company_type_raw,json=companyTypeRaw" json:"company_type_raw,omitempty"`
 }
 
 func (m *Company) Reset()                    { *m = Company{} }
@@ -255,6 +456,61 @@ func (m *Company) GetCompanyType() BUSINESS_ENTITY_TYPE {
 	return BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER
 }
 
+func (m *Company) GetCompanyTypeRaw() string {
+	if m != nil {
+		return m.CompanyTypeRaw
+	}
+	return ""
+}
+
+type Vehicles struct {
+	Count int32 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *Vehicles) Reset()                    { *m = Vehicles{} }
+func (m *Vehicles) String() string            { return proto.CompactTextString(m) }
+func (*Vehicles) ProtoMessage()               {}
+func (*Vehicles) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Vehicles) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type Insurance struct {
+	Company        string                     `protobuf:"bytes,1,opt,name=company" json:"company,omitempty"`
+	PolicyNumber   string                     `protobuf:"bytes,2,opt,name=policy_number,json=policyNumber" json:"policy_number,omitempty"`
+	ExpirationDate *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=expiration_date,json=expirationDate" json:"expiration_date,omitempty"`
+}
+
+func (m *Insurance) Reset()                    { *m = Insurance{} }
+func (m *Insurance) String() string            { return proto.CompactTextString(m) }
+func (*Insurance) ProtoMessage()               {}
+func (*Insurance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *Insurance) GetCompany() string {
+	if m != nil {
+		return m.Company
+	}
+	return ""
+}
+
+func (m *Insurance) GetPolicyNumber() string {
+	if m != nil {
+		return m.PolicyNumber
+	}
+	return ""
+}
+
+func (m *Insurance) GetExpirationDate() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ExpirationDate
+	}
+	return nil
+}
+
 type VTCEntry struct {
 	LegalEntityType    LEGAL_ENTITY_TYPE          `protobuf:"varint,1,opt,name=legal_entity_type,json=legalEntityType,enum=revtc.LEGAL_ENTITY_TYPE" json:"legal_entity_type,omitempty"`
 	CompanyNumber      string                     `protobuf:"bytes,2,opt,name=company_number,json=companyNumber" json:"company_number,omitempty"`
@@ -263,12 +519,23 @@ type VTCEntry struct {
 	Address            *Address                   `protobuf:"bytes,5,opt,name=address" json:"address,omitempty"`
 	Individual         *Individual                `protobuf:"bytes,6,opt,name=individual" json:"individual,omitempty"`
 	Company            *Company                   `protobuf:"bytes,7,opt,name=company" json:"company,omitempty"`
+	RecordId           int64                      `protobuf:"varint,8,opt,name=record_id,json=recordId" json:"record_id,omitempty"`
+	FetchedAt          *google_protobuf.Timestamp `protobuf:"bytes,9,opt,name=fetched_at,json=fetchedAt" json:"fetched_at,omitempty"`
+	FromCache          bool                       `protobuf:"varint,10,opt,name=from_cache,json=fromCache" json:"from_cache,omitempty"`
+	ValidityStatus     VALIDITY_STATUS            `protobuf:"varint,11,opt,name=validity_status,json=validityStatus,enum=revtc.VALIDITY_STATUS" json:"validity_status,omitempty"`
+	ExpirationDateRaw  string                     `protobuf:"bytes,12,opt,name=expiration_date_raw,json=expirationDateRaw" json:"expiration_date_raw,omitempty"`
+	RegistrationDate   *google_protobuf.Timestamp `protobuf:"bytes,13,opt,name=registration_date,json=registrationDate" json:"registration_date,omitempty"`
+	Phone              string                     `protobuf:"bytes,14,opt,name=phone" json:"phone,omitempty"`
+	Email              string                     `protobuf:"bytes,15,opt,name=email" json:"email,omitempty"`
+	Vehicles           *Vehicles                  `protobuf:"bytes,16,opt,name=vehicles" json:"vehicles,omitempty"`
+	Insurance          *Insurance                 `protobuf:"bytes,17,opt,name=insurance" json:"insurance,omitempty"`
+	RawFields          map[string]string          `protobuf:"bytes,18,rep,name=raw_fields,json=rawFields" json:"raw_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *VTCEntry) Reset()                    { *m = VTCEntry{} }
 func (m *VTCEntry) String() string            { return proto.CompactTextString(m) }
 func (*VTCEntry) ProtoMessage()               {}
-func (*VTCEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*VTCEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *VTCEntry) GetLegalEntityType() LEGAL_ENTITY_TYPE {
 	if m != nil {
@@ -319,6 +586,83 @@ func (m *VTCEntry) GetCompany() *Company {
 	return nil
 }
 
+func (m *VTCEntry) GetRecordId() int64 {
+	if m != nil {
+		return m.RecordId
+	}
+	return 0
+}
+
+func (m *VTCEntry) GetFetchedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.FetchedAt
+	}
+	return nil
+}
+
+func (m *VTCEntry) GetFromCache() bool {
+	if m != nil {
+		return m.FromCache
+	}
+	return false
+}
+
+func (m *VTCEntry) GetValidityStatus() VALIDITY_STATUS {
+	if m != nil {
+		return m.ValidityStatus
+	}
+	return VALIDITY_STATUS_VALIDITY_STATUS_UNKNOWN
+}
+
+func (m *VTCEntry) GetExpirationDateRaw() string {
+	if m != nil {
+		return m.ExpirationDateRaw
+	}
+	return ""
+}
+
+func (m *VTCEntry) GetRegistrationDate() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.RegistrationDate
+	}
+	return nil
+}
+
+func (m *VTCEntry) GetPhone() string {
+	if m != nil {
+		return m.Phone
+	}
+	return ""
+}
+
+func (m *VTCEntry) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *VTCEntry) GetVehicles() *Vehicles {
+	if m != nil {
+		return m.Vehicles
+	}
+	return nil
+}
+
+func (m *VTCEntry) GetInsurance() *Insurance {
+	if m != nil {
+		return m.Insurance
+	}
+	return nil
+}
+
+func (m *VTCEntry) GetRawFields() map[string]string {
+	if m != nil {
+		return m.RawFields
+	}
+	return nil
+}
+
 type SimpleInput struct {
 	Input string `protobuf:"bytes,1,opt,name=input" json:"input,omitempty"`
 }
@@ -326,7 +670,7 @@ type SimpleInput struct {
 func (m *SimpleInput) Reset()                    { *m = SimpleInput{} }
 func (m *SimpleInput) String() string            { return proto.CompactTextString(m) }
 func (*SimpleInput) ProtoMessage()               {}
-func (*SimpleInput) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*SimpleInput) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *SimpleInput) GetInput() string {
 	if m != nil {
@@ -335,16 +679,102 @@ func (m *SimpleInput) GetInput() string {
 	return ""
 }
 
+type LookupRequest struct {
+	CorrelationId string `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId" json:"correlation_id,omitempty"`
+	Input         string `protobuf:"bytes,2,opt,name=input" json:"input,omitempty"`
+}
+
+func (m *LookupRequest) Reset()                    { *m = LookupRequest{} }
+func (m *LookupRequest) String() string            { return proto.CompactTextString(m) }
+func (*LookupRequest) ProtoMessage()               {}
+func (*LookupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *LookupRequest) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *LookupRequest) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+type LookupError struct {
+	Code    int32  `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *LookupError) Reset()                    { *m = LookupError{} }
+func (m *LookupError) String() string            { return proto.CompactTextString(m) }
+func (*LookupError) ProtoMessage()               {}
+func (*LookupError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *LookupError) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *LookupError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type LookupResponse struct {
+	CorrelationId string       `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId" json:"correlation_id,omitempty"`
+	Entry         *VTCEntry    `protobuf:"bytes,2,opt,name=entry" json:"entry,omitempty"`
+	Error         *LookupError `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *LookupResponse) Reset()                    { *m = LookupResponse{} }
+func (m *LookupResponse) String() string            { return proto.CompactTextString(m) }
+func (*LookupResponse) ProtoMessage()               {}
+func (*LookupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *LookupResponse) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+func (m *LookupResponse) GetEntry() *VTCEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (m *LookupResponse) GetError() *LookupError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Address)(nil), "revtc.Address")
 	proto.RegisterType((*PersonName)(nil), "revtc.PersonName")
 	proto.RegisterType((*Individual)(nil), "revtc.Individual")
 	proto.RegisterType((*Company)(nil), "revtc.Company")
+	proto.RegisterType((*Vehicles)(nil), "revtc.Vehicles")
+	proto.RegisterType((*Insurance)(nil), "revtc.Insurance")
 	proto.RegisterType((*VTCEntry)(nil), "revtc.VTCEntry")
 	proto.RegisterType((*SimpleInput)(nil), "revtc.SimpleInput")
+	proto.RegisterType((*LookupRequest)(nil), "revtc.LookupRequest")
+	proto.RegisterType((*LookupError)(nil), "revtc.LookupError")
+	proto.RegisterType((*LookupResponse)(nil), "revtc.LookupResponse")
 	proto.RegisterEnum("revtc.PERSON_TITLE", PERSON_TITLE_name, PERSON_TITLE_value)
 	proto.RegisterEnum("revtc.LEGAL_ENTITY_TYPE", LEGAL_ENTITY_TYPE_name, LEGAL_ENTITY_TYPE_value)
 	proto.RegisterEnum("revtc.BUSINESS_ENTITY_TYPE", BUSINESS_ENTITY_TYPE_name, BUSINESS_ENTITY_TYPE_value)
+	proto.RegisterEnum("revtc.VALIDITY_STATUS", VALIDITY_STATUS_name, VALIDITY_STATUS_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -359,6 +789,7 @@ const _ = grpc.SupportPackageIsVersion4
 
 type ReVTCClient interface {
 	GetBySIREN(ctx context.Context, in *SimpleInput, opts ...grpc.CallOption) (*VTCEntry, error)
+	Lookup(ctx context.Context, opts ...grpc.CallOption) (ReVTC_LookupClient, error)
 }
 
 type reVTCClient struct {
@@ -378,10 +809,42 @@ func (c *reVTCClient) GetBySIREN(ctx context.Context, in *SimpleInput, opts ...g
 	return out, nil
 }
 
+func (c *reVTCClient) Lookup(ctx context.Context, opts ...grpc.CallOption) (ReVTC_LookupClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ReVTC_serviceDesc.Streams[0], c.cc, "/revtc.ReVTC/Lookup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reVTCLookupClient{stream}
+	return x, nil
+}
+
+type ReVTC_LookupClient interface {
+	Send(*LookupRequest) error
+	Recv() (*LookupResponse, error)
+	grpc.ClientStream
+}
+
+type reVTCLookupClient struct {
+	grpc.ClientStream
+}
+
+func (x *reVTCLookupClient) Send(m *LookupRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *reVTCLookupClient) Recv() (*LookupResponse, error) {
+	m := new(LookupResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for ReVTC service
 
 type ReVTCServer interface {
 	GetBySIREN(context.Context, *SimpleInput) (*VTCEntry, error)
+	Lookup(ReVTC_LookupServer) error
 }
 
 func RegisterReVTCServer(s *grpc.Server, srv ReVTCServer) {
@@ -406,6 +869,32 @@ func _ReVTC_GetBySIREN_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReVTC_Lookup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReVTCServer).Lookup(&reVTCLookupServer{stream})
+}
+
+type ReVTC_LookupServer interface {
+	Send(*LookupResponse) error
+	Recv() (*LookupRequest, error)
+	grpc.ServerStream
+}
+
+type reVTCLookupServer struct {
+	grpc.ServerStream
+}
+
+func (x *reVTCLookupServer) Send(m *LookupResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *reVTCLookupServer) Recv() (*LookupRequest, error) {
+	m := new(LookupRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _ReVTC_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "revtc.ReVTC",
 	HandlerType: (*ReVTCServer)(nil),
@@ -415,56 +904,118 @@ var _ReVTC_serviceDesc = grpc.ServiceDesc{
 			Handler:    _ReVTC_GetBySIREN_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Lookup",
+			Handler:       _ReVTC_Lookup_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "revtc.proto",
 }
 
 func init() { proto.RegisterFile("revtc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 689 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0x6d, 0x4f, 0xe2, 0x4c,
-	0x14, 0x15, 0x04, 0xd1, 0x8b, 0x0f, 0xd4, 0xd1, 0x3c, 0xf6, 0x01, 0xdf, 0xc2, 0x13, 0x13, 0xd7,
-	0x4d, 0x30, 0x8b, 0x5f, 0x37, 0x9b, 0x20, 0x34, 0xda, 0x04, 0x2b, 0x3b, 0x2d, 0x26, 0x7e, 0xd9,
-	0x66, 0xa0, 0x23, 0x69, 0xd2, 0xb7, 0x6d, 0x07, 0x63, 0xff, 0xda, 0xfe, 0x88, 0xfd, 0x35, 0xfb,
-	0x03, 0x36, 0x9d, 0x99, 0x22, 0x2c, 0xb8, 0xdf, 0x7a, 0xcf, 0xb9, 0xf7, 0xcc, 0xcc, 0xb9, 0xa7,
-	0x50, 0x8d, 0xe9, 0x0b, 0x9b, 0xb4, 0xa3, 0x38, 0x64, 0x21, 0x2a, 0xf3, 0xa2, 0x71, 0x3a, 0x0d,
-	0xc3, 0xa9, 0x47, 0xaf, 0x38, 0x38, 0x9e, 0x3d, 0x5f, 0x31, 0xd7, 0xa7, 0x09, 0x23, 0x7e, 0x24,
-	0xfa, 0x5a, 0xaf, 0x50, 0xe9, 0x3a, 0x4e, 0x4c, 0x93, 0x04, 0x9d, 0x42, 0x35, 0x0a, 0x13, 0x46,
-	0x3c, 0x7b, 0x12, 0x3a, 0x54, 0x2d, 0x9c, 0x15, 0x2e, 0x76, 0x30, 0x08, 0xa8, 0x17, 0x3a, 0x14,
-	0x21, 0x28, 0x4d, 0x5c, 0x96, 0xaa, 0x45, 0xce, 0xf0, 0x6f, 0xa4, 0x42, 0x65, 0x12, 0xce, 0x02,
-	0x16, 0xa7, 0xea, 0x26, 0x87, 0xf3, 0x12, 0x9d, 0x00, 0x38, 0x34, 0x22, 0x31, 0xf3, 0x69, 0xc0,
-	0xd4, 0x92, 0x50, 0x7b, 0x43, 0x5a, 0x77, 0x00, 0x43, 0x1a, 0x27, 0x61, 0x60, 0x10, 0x9f, 0xa2,
-	0x26, 0xec, 0x78, 0x24, 0x61, 0x76, 0x40, 0xfc, 0xfc, 0xe8, 0xed, 0x0c, 0xe0, 0xe4, 0x31, 0xc0,
-	0xb3, 0x1b, 0xe7, 0xac, 0x38, 0x7e, 0x87, 0x23, 0x19, 0xdd, 0xfa, 0x06, 0xa0, 0x07, 0x8e, 0xfb,
-	0xe2, 0x3a, 0x33, 0xe2, 0xa1, 0x0f, 0x50, 0x66, 0x2e, 0xf3, 0x84, 0x4a, 0xad, 0xb3, 0xdf, 0x16,
-	0xb6, 0x0c, 0x35, 0x6c, 0x3e, 0x18, 0xb6, 0xa5, 0x5b, 0x03, 0x0d, 0x8b, 0x0e, 0x74, 0x0e, 0xa5,
-	0xb9, 0x62, 0xb5, 0xb3, 0x97, 0x77, 0xce, 0x6f, 0x85, 0x39, 0xdd, 0xfa, 0x51, 0x80, 0x4a, 0x2f,
-	0xf4, 0x23, 0x12, 0xa4, 0x99, 0x07, 0x0b, 0x57, 0xe4, 0xdf, 0x99, 0x07, 0x64, 0x12, 0x87, 0x41,
-	0xea, 0xcb, 0xbb, 0xe5, 0x25, 0x3a, 0x80, 0xf2, 0x38, 0x26, 0x81, 0x23, 0xbd, 0x11, 0x05, 0xfa,
-	0x98, 0x79, 0x16, 0x30, 0x32, 0x11, 0xb6, 0xac, 0x3d, 0x39, 0xef, 0x40, 0x5f, 0x60, 0x77, 0x22,
-	0xce, 0xb6, 0x59, 0x1a, 0x51, 0xb5, 0xcc, 0x5f, 0xd5, 0x94, 0x13, 0x37, 0x23, 0x53, 0x37, 0x34,
-	0xd3, 0xb4, 0x35, 0xc3, 0xd2, 0xad, 0x27, 0xdb, 0x7a, 0x1a, 0x6a, 0xb8, 0x2a, 0x07, 0xac, 0x34,
-	0xa2, 0xad, 0x5f, 0x45, 0xd8, 0x7e, 0xb4, 0x7a, 0x1a, 0xdf, 0x49, 0x1f, 0xf6, 0x3c, 0x3a, 0x25,
-	0x9e, 0x4d, 0x03, 0xe6, 0x32, 0xa9, 0x28, 0x7c, 0x52, 0xa5, 0xe2, 0x40, 0xbb, 0xed, 0x0e, 0x96,
-	0xe4, 0xea, 0x7c, 0x44, 0xe3, 0x13, 0x99, 0x24, 0x3a, 0x87, 0x5a, 0x7e, 0xa5, 0x60, 0xe6, 0x8f,
-	0x69, 0x2c, 0x9f, 0xfd, 0x8f, 0x44, 0x0d, 0x0e, 0xa2, 0x2b, 0xd8, 0x8f, 0xe9, 0xd4, 0x4d, 0x58,
-	0x4c, 0x98, 0x1b, 0x06, 0x79, 0xaf, 0xb0, 0x02, 0x2d, 0x52, 0x72, 0xa0, 0x07, 0x75, 0xfa, 0x1a,
-	0xb9, 0xb2, 0xdd, 0x21, 0x8c, 0x4a, 0x7f, 0x1a, 0x6d, 0x11, 0xe3, 0x76, 0x1e, 0xe3, 0xb6, 0x95,
-	0xc7, 0x18, 0xd7, 0xde, 0x46, 0xfa, 0x84, 0x51, 0x74, 0x01, 0x15, 0x22, 0x02, 0xcd, 0xad, 0xaa,
-	0x76, 0x6a, 0xf2, 0x61, 0x32, 0xe6, 0x38, 0xa7, 0xd1, 0x27, 0x00, 0x77, 0x1e, 0x1b, 0x75, 0x6b,
-	0x69, 0x13, 0x6f, 0x79, 0xc2, 0x0b, 0x4d, 0x99, 0xb8, 0x7c, 0xa3, 0x5a, 0x59, 0x12, 0x97, 0xf1,
-	0xc0, 0x39, 0xdd, 0xfa, 0x1f, 0xaa, 0xa6, 0xeb, 0x47, 0x1e, 0xd5, 0x83, 0x68, 0xc6, 0xb2, 0x20,
-	0xb8, 0xd9, 0x87, 0xcc, 0x8d, 0x28, 0x2e, 0xbf, 0xc2, 0xee, 0x62, 0x2c, 0xd1, 0xbf, 0x80, 0x16,
-	0x6b, 0xfb, 0xc1, 0xba, 0xd3, 0xb0, 0xb2, 0x81, 0xf6, 0xa1, 0xbe, 0x84, 0xdf, 0x63, 0xa5, 0x80,
-	0x0e, 0x40, 0xf9, 0x03, 0x34, 0x95, 0xe2, 0xe5, 0x77, 0xd8, 0x5b, 0xd9, 0x20, 0x6a, 0xc2, 0xe1,
-	0x0a, 0x38, 0x17, 0x3f, 0x86, 0xff, 0x56, 0xc9, 0xde, 0xc3, 0xfd, 0xb0, 0x6b, 0x3c, 0x29, 0x05,
-	0x74, 0x06, 0x47, 0xab, 0xb4, 0x6e, 0xf4, 0xf5, 0x47, 0xbd, 0x3f, 0xea, 0x0e, 0x94, 0xe2, 0xe5,
-	0xcf, 0x02, 0x1c, 0xac, 0xcb, 0x21, 0x3a, 0x81, 0xc6, 0x3a, 0x7c, 0x7e, 0x72, 0x13, 0x0e, 0xd7,
-	0xf2, 0x66, 0x57, 0x29, 0x64, 0xd7, 0x7a, 0x87, 0xc4, 0x03, 0xa5, 0x88, 0x8e, 0x40, 0x7d, 0x87,
-	0x36, 0x95, 0xcd, 0xbf, 0x0c, 0x9b, 0x23, 0xa5, 0xf4, 0x2e, 0xad, 0x8d, 0xf0, 0x40, 0x29, 0x77,
-	0x3e, 0x43, 0x19, 0xd3, 0x47, 0xab, 0x87, 0xae, 0x01, 0x6e, 0x29, 0xbb, 0x49, 0x4d, 0x1d, 0x6b,
-	0x06, 0x42, 0x72, 0xd7, 0x0b, 0x7b, 0x6d, 0xd4, 0x25, 0x96, 0xff, 0x61, 0xad, 0x8d, 0xf1, 0x16,
-	0x0f, 0xe9, 0xf5, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0xcb, 0x13, 0xb3, 0x11, 0x8f, 0x05, 0x00,
-	0x00,
+	// 1576 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x72, 0xdb, 0xc8,
+	0x11, 0x36, 0x29, 0x51, 0x24, 0x9b, 0x12, 0x39, 0x1a, 0xc9, 0x36, 0x2c, 0xd9, 0x96, 0x96, 0xb2,
+	0xd7, 0x8c, 0x5c, 0x25, 0x27, 0xda, 0x4b, 0x92, 0xcd, 0x4f, 0xc1, 0xd0, 0x48, 0x8b, 0x0a, 0x05,
+	0x32, 0x03, 0x52, 0x89, 0x4f, 0x28, 0x98, 0x18, 0x4b, 0xa8, 0x05, 0x01, 0x2e, 0x00, 0x4a, 0x61,
+	0xce, 0x39, 0xe4, 0x96, 0x07, 0xc9, 0x8b, 0xe4, 0x41, 0xf2, 0x00, 0xb9, 0xe7, 0x92, 0x9a, 0x1f,
+	0xf0, 0x4f, 0x04, 0x37, 0xa9, 0xca, 0x0d, 0xfd, 0x7d, 0x3d, 0x3d, 0xdd, 0xfd, 0xcd, 0xf4, 0x00,
+	0x6a, 0x31, 0xbb, 0x4f, 0x07, 0x67, 0xa3, 0x38, 0x4a, 0x23, 0x5c, 0x12, 0xc6, 0xc1, 0xd1, 0x6d,
+	0x14, 0xdd, 0x06, 0xec, 0x83, 0x00, 0x3f, 0x8f, 0xbf, 0x7c, 0x48, 0xfd, 0x21, 0x4b, 0x52, 0x77,
+	0x38, 0x92, 0x7e, 0xcd, 0x7f, 0x15, 0xa1, 0xac, 0x7b, 0x5e, 0xcc, 0x92, 0x04, 0x1f, 0x41, 0x6d,
+	0x14, 0x25, 0xa9, 0x1b, 0x38, 0x83, 0xc8, 0x63, 0x5a, 0xe1, 0xb8, 0xd0, 0xaa, 0x52, 0x90, 0x90,
+	0x11, 0x79, 0x0c, 0x63, 0xd8, 0x1c, 0xf8, 0xe9, 0x44, 0x2b, 0x0a, 0x46, 0x7c, 0x63, 0x0d, 0xca,
+	0x83, 0x68, 0x1c, 0xa6, 0xf1, 0x44, 0xdb, 0x10, 0x70, 0x66, 0xe2, 0xd7, 0x00, 0x1e, 0x1b, 0xb9,
+	0x71, 0x3a, 0x64, 0x61, 0xaa, 0x6d, 0xca, 0x68, 0x33, 0x04, 0xef, 0x43, 0x29, 0xf0, 0x43, 0x96,
+	0x68, 0xa5, 0xe3, 0x8d, 0x56, 0x95, 0x4a, 0x03, 0x9f, 0xc2, 0xee, 0x5c, 0x12, 0xce, 0xbd, 0x1b,
+	0xf8, 0x9e, 0xb6, 0x75, 0x5c, 0x68, 0x55, 0x68, 0x63, 0x96, 0xca, 0x0d, 0x87, 0xf1, 0x3b, 0x68,
+	0xcc, 0xe2, 0xc9, 0xa4, 0xcb, 0x62, 0x9b, 0xfa, 0x0c, 0x16, 0x89, 0x2f, 0x3a, 0x86, 0xee, 0x90,
+	0x69, 0x95, 0x65, 0x47, 0xcb, 0x1d, 0x32, 0xfc, 0x0c, 0xb6, 0x62, 0x76, 0xeb, 0x47, 0xa1, 0x56,
+	0x15, 0xbc, 0xb2, 0x78, 0x00, 0x5e, 0xad, 0x13, 0x46, 0xf1, 0xd0, 0x0d, 0xfc, 0x3f, 0x33, 0x4f,
+	0x03, 0x19, 0x80, 0xc3, 0xd6, 0x14, 0xc5, 0x5f, 0xc1, 0xb6, 0xaa, 0x5f, 0xe6, 0x53, 0x13, 0x5e,
+	0x35, 0x85, 0xf1, 0x64, 0x9a, 0xdf, 0x01, 0x74, 0x59, 0x9c, 0x44, 0xa1, 0xd8, 0xf1, 0x10, 0xaa,
+	0x81, 0x9b, 0xa8, 0xa4, 0x64, 0xcb, 0x2b, 0x1c, 0x10, 0xe4, 0x2b, 0x80, 0x2f, 0x7e, 0x9c, 0xb1,
+	0xb2, 0xed, 0x55, 0x81, 0x70, 0xba, 0xf9, 0x8f, 0x02, 0x80, 0x19, 0x7a, 0xfe, 0xbd, 0xef, 0x8d,
+	0xdd, 0x00, 0xff, 0x04, 0x4a, 0xa9, 0x9f, 0x06, 0x32, 0x4c, 0xfd, 0x7c, 0xef, 0x4c, 0x1e, 0x88,
+	0x2e, 0xa1, 0x76, 0xc7, 0x72, 0x7a, 0x66, 0xaf, 0x4d, 0xa8, 0xf4, 0xc0, 0x6f, 0x61, 0x73, 0x1a,
+	0xb2, 0x76, 0xbe, 0x9b, 0x79, 0x4e, 0xd3, 0xa2, 0x82, 0xc6, 0xbf, 0xe1, 0xd5, 0x0c, 0x47, 0x6e,
+	0x38, 0x71, 0xd2, 0xc9, 0x88, 0x09, 0x85, 0xeb, 0xe7, 0x87, 0xca, 0xfd, 0x63, 0xdf, 0x36, 0x2d,
+	0x62, 0xdb, 0x0e, 0xb1, 0x7a, 0x66, 0xef, 0x93, 0xd3, 0xfb, 0xd4, 0x25, 0xb4, 0xa6, 0x16, 0xf4,
+	0x26, 0x23, 0x86, 0x5b, 0x80, 0xe6, 0xd7, 0x3b, 0xb1, 0xfb, 0xa0, 0x0e, 0x42, 0x7d, 0xce, 0x8d,
+	0xba, 0x0f, 0xcd, 0x7f, 0x16, 0xa0, 0x6c, 0x48, 0x88, 0x1f, 0xb3, 0xb9, 0x6e, 0xc8, 0x4c, 0x34,
+	0x28, 0xbb, 0x83, 0x38, 0x0a, 0x27, 0x43, 0xd5, 0x86, 0xcc, 0xe4, 0xc7, 0xe8, 0x73, 0xec, 0x86,
+	0x9e, 0x3a, 0x7e, 0xd2, 0xc0, 0xef, 0xf9, 0xb1, 0x0c, 0x53, 0x77, 0x20, 0x4f, 0xde, 0xca, 0x1a,
+	0x33, 0x8f, 0x47, 0x65, 0x96, 0xfe, 0x0f, 0x65, 0x6e, 0xad, 0x2c, 0xf3, 0x18, 0x2a, 0x37, 0xec,
+	0xce, 0x1f, 0x04, 0x2c, 0xe1, 0x89, 0x8b, 0x63, 0x21, 0xea, 0x2c, 0x51, 0x69, 0x34, 0xff, 0x56,
+	0x80, 0xaa, 0x19, 0x26, 0xe3, 0xd8, 0x0d, 0x07, 0x4c, 0xde, 0x2e, 0x11, 0x41, 0x75, 0x23, 0x33,
+	0xf1, 0x09, 0xec, 0x8c, 0xa2, 0xc0, 0x1f, 0x4c, 0x9c, 0x70, 0x3c, 0xfc, 0xcc, 0x62, 0xd5, 0x96,
+	0x6d, 0x09, 0x5a, 0x02, 0xc3, 0x06, 0x34, 0xd8, 0x9f, 0x46, 0x7e, 0xec, 0xa6, 0x7e, 0x14, 0x3a,
+	0x9e, 0x9b, 0x4a, 0x09, 0x6b, 0xe7, 0x07, 0x67, 0x72, 0x30, 0x9c, 0x65, 0x83, 0xe1, 0xac, 0x97,
+	0x0d, 0x06, 0x5a, 0x9f, 0x2d, 0xb9, 0x70, 0x53, 0xd6, 0xfc, 0x7b, 0x19, 0x2a, 0x37, 0x3d, 0x83,
+	0x88, 0x4b, 0x7d, 0x01, 0xbb, 0x01, 0xbb, 0x75, 0x03, 0x87, 0x85, 0xa9, 0x9f, 0xca, 0x7a, 0xd5,
+	0x79, 0xd3, 0x54, 0xbf, 0xda, 0xe4, 0x4a, 0x6f, 0x2f, 0x34, 0xab, 0x21, 0x96, 0x10, 0xb1, 0x42,
+	0x34, 0xec, 0x2d, 0x64, 0x8d, 0x59, 0xcc, 0x7e, 0x47, 0xa1, 0x2a, 0xfd, 0x0f, 0xb0, 0xc7, 0xef,
+	0x5f, 0x92, 0xaa, 0x02, 0x94, 0xaf, 0x14, 0x1a, 0xcf, 0x53, 0xf9, 0xf5, 0x6e, 0xfe, 0xaf, 0xf5,
+	0xe2, 0x16, 0x94, 0x5d, 0x39, 0x11, 0xc5, 0x41, 0xa8, 0x9d, 0xd7, 0x55, 0x61, 0x6a, 0x4e, 0xd2,
+	0x8c, 0xc6, 0x3f, 0x03, 0xf0, 0xa7, 0xd7, 0x4f, 0xdb, 0x5a, 0x38, 0x67, 0xb3, 0x7b, 0x49, 0xe7,
+	0x9c, 0x78, 0xf0, 0x4c, 0xd0, 0xf2, 0x42, 0x70, 0x75, 0xf8, 0x67, 0x02, 0x1f, 0x42, 0x35, 0x66,
+	0x83, 0x28, 0xf6, 0x1c, 0xdf, 0x13, 0xd3, 0x6a, 0x83, 0x56, 0x24, 0x60, 0x7a, 0xf8, 0x17, 0x00,
+	0x5f, 0x58, 0x3a, 0xb8, 0x63, 0x9e, 0xe3, 0xa6, 0x5a, 0xf5, 0x47, 0x6b, 0xac, 0x2a, 0x6f, 0x3d,
+	0x15, 0x33, 0x25, 0x8e, 0x86, 0xce, 0xc0, 0x1d, 0xdc, 0x31, 0x31, 0xc5, 0x2a, 0xb4, 0xca, 0x11,
+	0x83, 0x03, 0xf8, 0xb7, 0xd0, 0x10, 0x33, 0x97, 0x8b, 0x9b, 0xa4, 0x6e, 0x3a, 0x4e, 0xc4, 0x0c,
+	0xab, 0x9f, 0x3f, 0x53, 0x89, 0xde, 0xe8, 0x6d, 0xf3, 0x82, 0x4b, 0x6b, 0xf7, 0xf4, 0x5e, 0xdf,
+	0xa6, 0xf5, 0xcc, 0xdd, 0x16, 0xde, 0xf8, 0x0c, 0xf6, 0x96, 0x34, 0x10, 0xf7, 0x61, 0x5b, 0x88,
+	0xb6, 0xbb, 0xd8, 0x6b, 0xea, 0x3e, 0xe0, 0x2b, 0xd8, 0x5d, 0x10, 0x59, 0xa8, 0xb6, 0xf3, 0xa3,
+	0x15, 0xa1, 0xf9, 0x45, 0x42, 0xb7, 0x7d, 0x28, 0x8d, 0xee, 0xa2, 0x90, 0x69, 0x75, 0x39, 0x08,
+	0x84, 0xc1, 0x51, 0x36, 0x74, 0xfd, 0x40, 0x6b, 0x48, 0x54, 0x18, 0xf8, 0x3d, 0x54, 0xee, 0xd5,
+	0x3d, 0xd4, 0x90, 0xd8, 0xab, 0x91, 0x95, 0xa7, 0x60, 0x3a, 0x75, 0xc0, 0x67, 0x50, 0xf5, 0xb3,
+	0x1b, 0xa9, 0xed, 0x0a, 0x6f, 0x34, 0x55, 0x59, 0xe1, 0x74, 0xe6, 0x82, 0x7f, 0x0d, 0x10, 0xbb,
+	0x0f, 0xce, 0x17, 0x9f, 0x05, 0x5e, 0xa2, 0xe1, 0xe3, 0x8d, 0x56, 0xed, 0xfc, 0x75, 0x16, 0x5e,
+	0x5d, 0xa4, 0x33, 0xea, 0x3e, 0x5c, 0x0a, 0x07, 0x61, 0xd2, 0x6a, 0x9c, 0xd9, 0x07, 0xbf, 0x82,
+	0xfa, 0x22, 0x89, 0x11, 0x6c, 0x7c, 0xcf, 0xb2, 0x09, 0xc0, 0x3f, 0x79, 0x55, 0xf7, 0x6e, 0x30,
+	0xce, 0xde, 0x04, 0x69, 0xfc, 0xb2, 0xf8, 0xf3, 0x42, 0xf3, 0x04, 0x6a, 0xb6, 0x3f, 0x1c, 0x05,
+	0xcc, 0x0c, 0x47, 0x63, 0xf1, 0xc8, 0xfa, 0xfc, 0x43, 0x2d, 0x96, 0x46, 0xb3, 0x0d, 0x3b, 0xed,
+	0x28, 0xfa, 0x7e, 0x3c, 0xa2, 0xec, 0x87, 0x31, 0x4b, 0x52, 0x79, 0x21, 0xe3, 0x98, 0x05, 0x52,
+	0x03, 0xdf, 0x53, 0xfe, 0x3b, 0x73, 0xa8, 0xe9, 0xcd, 0xa2, 0x15, 0xe7, 0xa3, 0x7d, 0x0b, 0x35,
+	0x19, 0x8d, 0xc4, 0x71, 0x14, 0x8b, 0xbf, 0x84, 0xec, 0xff, 0xa1, 0x44, 0xc5, 0x37, 0x9f, 0x63,
+	0x43, 0x96, 0x24, 0xee, 0x6d, 0x96, 0x71, 0x66, 0x36, 0xff, 0x5a, 0x80, 0x7a, 0x96, 0x4b, 0x32,
+	0x8a, 0xc2, 0x84, 0xfd, 0xb7, 0xc9, 0xbc, 0x85, 0x12, 0x13, 0xff, 0x1d, 0xc5, 0x45, 0x01, 0x55,
+	0x87, 0xa9, 0x64, 0x71, 0x0b, 0x4a, 0x8c, 0xe7, 0xa5, 0x26, 0x1f, 0xce, 0xa6, 0xd4, 0x2c, 0x63,
+	0x2a, 0x1d, 0x4e, 0x7f, 0x0f, 0xdb, 0xf3, 0x6f, 0x25, 0x7e, 0x06, 0x78, 0xde, 0x76, 0x3a, 0xbd,
+	0xef, 0x08, 0x45, 0x4f, 0xf0, 0x1e, 0x34, 0x16, 0xf0, 0x6b, 0x8a, 0x0a, 0x78, 0x1f, 0xd0, 0x12,
+	0x68, 0xa3, 0xe2, 0xe9, 0x0f, 0xb0, 0xfb, 0x68, 0x1c, 0xe2, 0x43, 0x78, 0xfe, 0x08, 0x9c, 0x06,
+	0x7f, 0x05, 0x2f, 0x1e, 0x93, 0x46, 0xe7, 0xba, 0xab, 0x5b, 0x9f, 0x50, 0x01, 0x1f, 0xc3, 0xcb,
+	0xc7, 0xb4, 0x69, 0x5d, 0x98, 0x37, 0xe6, 0x45, 0x5f, 0x6f, 0xa3, 0xe2, 0xe9, 0xbf, 0xab, 0xb0,
+	0xbf, 0xea, 0xc9, 0xc2, 0xaf, 0xe1, 0x60, 0x15, 0x3e, 0xdd, 0xf9, 0x10, 0x9e, 0xaf, 0xe4, 0x6d,
+	0x1d, 0x15, 0x78, 0x5a, 0x39, 0x24, 0x6d, 0xa3, 0x22, 0x7e, 0x09, 0x5a, 0x0e, 0x6d, 0xa3, 0x8d,
+	0x35, 0x8b, 0xed, 0x3e, 0xda, 0xcc, 0xa5, 0x49, 0x9f, 0xb6, 0x51, 0x29, 0x37, 0x2f, 0x62, 0xa2,
+	0x2d, 0xfc, 0x1e, 0xde, 0xad, 0x24, 0xaf, 0x4d, 0x83, 0x76, 0x38, 0x42, 0x49, 0x97, 0x12, 0x8b,
+	0xf4, 0x29, 0x2a, 0xe7, 0x6f, 0x64, 0xd2, 0x36, 0xaa, 0xe4, 0x17, 0x61, 0x19, 0xa8, 0x9a, 0xcf,
+	0x1a, 0x36, 0x82, 0x35, 0xac, 0x8e, 0x6a, 0xf9, 0x0d, 0x30, 0x3a, 0x5d, 0xb4, 0xbd, 0x86, 0x36,
+	0x0d, 0xb4, 0x83, 0xdf, 0xc0, 0xf1, 0x4a, 0xda, 0xe8, 0x74, 0xba, 0x84, 0xea, 0x3d, 0xf3, 0x86,
+	0xa0, 0x3a, 0x3e, 0x82, 0xc3, 0xd5, 0x41, 0x48, 0x9b, 0x6b, 0xd4, 0xc8, 0xd5, 0x9f, 0x3b, 0xd8,
+	0x08, 0xad, 0x0d, 0x70, 0xa9, 0xa3, 0xdd, 0x35, 0x35, 0x9a, 0x08, 0xaf, 0x61, 0xbb, 0x68, 0x2f,
+	0x9f, 0x25, 0xd7, 0x68, 0x3f, 0x97, 0xbd, 0x32, 0x09, 0x7a, 0x9a, 0x5b, 0xbf, 0x6e, 0xdb, 0x1d,
+	0xc3, 0xd4, 0x7b, 0x66, 0xc7, 0x42, 0xcf, 0xf0, 0xd7, 0xd0, 0x5c, 0xe9, 0xd5, 0xed, 0x7f, 0x6c,
+	0x9b, 0x86, 0x82, 0xd0, 0x73, 0xfc, 0x0e, 0x4e, 0x56, 0x67, 0xd2, 0x31, 0x4c, 0xd2, 0x23, 0x8e,
+	0x61, 0xde, 0x98, 0x6d, 0x82, 0xb4, 0x35, 0x05, 0x5d, 0xa3, 0x17, 0xb9, 0x9a, 0x5d, 0xe9, 0xc4,
+	0x40, 0x07, 0xb9, 0x34, 0xe1, 0x5a, 0x1c, 0xae, 0x51, 0x9c, 0xe8, 0xe8, 0x65, 0xfe, 0x55, 0x24,
+	0xe8, 0xd5, 0x3a, 0x1d, 0x0d, 0x1d, 0xbd, 0x5e, 0xd3, 0xea, 0x2e, 0x3a, 0xc2, 0x27, 0x70, 0xb4,
+	0x92, 0x95, 0x33, 0xc4, 0xe6, 0xbd, 0x3c, 0xc6, 0x2d, 0x78, 0xb3, 0xd2, 0xe9, 0xb2, 0x43, 0x89,
+	0x79, 0x65, 0x4d, 0xe7, 0xd1, 0x57, 0x6b, 0x94, 0xeb, 0xa2, 0x66, 0xee, 0x66, 0x97, 0x9d, 0xbe,
+	0x75, 0x21, 0x85, 0x3b, 0xc9, 0x3d, 0x77, 0xd7, 0xfd, 0x1e, 0x9f, 0x68, 0x6f, 0xf0, 0x29, 0x7c,
+	0xbd, 0x5a, 0x59, 0xda, 0xb9, 0x24, 0x36, 0x4f, 0x59, 0x6f, 0x3b, 0x1f, 0x3b, 0x17, 0x9f, 0xd0,
+	0xdb, 0xd3, 0xbf, 0x14, 0xa0, 0xb1, 0xf4, 0x87, 0xc2, 0xbb, 0xb9, 0x04, 0x39, 0x7d, 0xeb, 0x77,
+	0x56, 0xe7, 0x0f, 0x16, 0x7a, 0x82, 0x5f, 0xc0, 0xd3, 0x65, 0x52, 0xd8, 0xa8, 0xc0, 0x6b, 0x5b,
+	0xa6, 0xc8, 0x1f, 0xbb, 0x26, 0x35, 0xad, 0x2b, 0x54, 0x5c, 0x15, 0x55, 0xb0, 0xe4, 0x02, 0x6d,
+	0x9c, 0x4f, 0xa0, 0x44, 0xd9, 0x4d, 0xcf, 0xc0, 0xdf, 0x00, 0x5c, 0xb1, 0xf4, 0xe3, 0xc4, 0x36,
+	0x29, 0xb1, 0x70, 0xf6, 0xf8, 0xcc, 0xbd, 0xd0, 0x07, 0xcb, 0xef, 0x56, 0xf3, 0x09, 0xfe, 0x16,
+	0xb6, 0xe4, 0xf3, 0x84, 0xf7, 0x17, 0x5e, 0x2b, 0xf5, 0x5a, 0x1f, 0x3c, 0x5d, 0x42, 0xe5, 0xbb,
+	0xd9, 0x7c, 0xd2, 0x2a, 0xfc, 0xb4, 0xf0, 0x79, 0x4b, 0xfc, 0x2c, 0x7d, 0xf3, 0x9f, 0x01, 0x00,
+	0x04, 0x89, 0xce, 0x69, 0x0f, 0x10, 0x00, 0x00,
 }
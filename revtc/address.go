@@ -0,0 +1,161 @@
+package revtc
+
+import (
+	pb "github.com/united-drivers/go-revtc/proto"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const franceCountryCode = "FR"
+
+// frenchOverseasCountryCodes are the ISO 3166-1 codes of the overseas
+// departments and collectivities, whose addresses use French postal codes.
+var frenchOverseasCountryCodes = map[string]bool{
+	"GP": true, "MQ": true, "GF": true, "RE": true, "YT": true, "PM": true,
+	"BL": true, "MF": true, "WF": true, "PF": true, "NC": true,
+}
+
+var departmentCodePattern = regexp.MustCompile(`^\s*(\d{3}|\d{2}|2[AaBb])\b`)
+
+var departmentsByCode = map[string]department{}
+
+var countryCodes = map[string]string{}
+
+func init() {
+	for _, d := range departments {
+		departmentsByCode[d.code] = d
+	}
+
+	for name, code := range countryNames {
+		countryCodes[strings.Join(normalizeWords(name), " ")] = code
+	}
+}
+
+// NormalizeAddress fills the derived fields of addr from its free-text
+// fields: postal code validity, department code, name and region, a
+// normalized city name and the ISO 3166 country code.
+func NormalizeAddress(addr *pb.Address) {
+	addr.PostalCode = strings.Replace(strings.TrimSpace(addr.PostalCode), " ", "", -1)
+	addr.CountryCode = countryCode(addr.Country)
+	addr.CityNormalized = normalizeCity(addr.City)
+
+	// a country missing from the table is foreign, not French
+	french := strings.TrimSpace(addr.Country) == "" ||
+		addr.CountryCode == franceCountryCode ||
+		frenchOverseasCountryCodes[addr.CountryCode]
+
+	if !french {
+		return
+	}
+
+	code := departmentFromPostalCode(addr.PostalCode)
+	addr.PostalCodeValid = code != ""
+
+	// the department label wins, it is what the registry filed
+	if match := departmentCodePattern.FindStringSubmatch(addr.Department); match != nil {
+		if _, ok := departmentsByCode[strings.ToUpper(match[1])]; ok {
+			code = strings.ToUpper(match[1])
+		}
+	}
+
+	if d, ok := departmentsByCode[code]; ok {
+		addr.DepartmentCode = d.code
+		addr.DepartmentName = d.name
+		addr.Region = d.region
+
+		if addr.CountryCode == "" {
+			addr.CountryCode = franceCountryCode
+		}
+	}
+}
+
+// departmentFromPostalCode returns the department a French postal code
+// belongs to, or "" if it is not a valid postal code.
+func departmentFromPostalCode(postalCode string) string {
+	if len(postalCode) != 5 {
+		return ""
+	}
+
+	number, err := strconv.Atoi(postalCode)
+
+	if err != nil || number < 1000 {
+		return ""
+	}
+
+	var code string
+
+	switch prefix := postalCode[:2]; {
+	case prefix == "20":
+		// Corse-du-Sud uses 200xx and 201xx, Haute-Corse 202xx to 206xx
+		if number < 20200 {
+			code = "2A"
+		} else {
+			code = "2B"
+		}
+
+	case postalCode == "97133":
+		code = "977"
+
+	case postalCode == "97150":
+		code = "978"
+
+	case prefix == "97" || prefix == "98":
+		code = postalCode[:3]
+
+	default:
+		code = prefix
+	}
+
+	if _, ok := departmentsByCode[code]; !ok {
+		return ""
+	}
+
+	return code
+}
+
+// countryCode returns the ISO 3166-1 alpha-2 code of a country name, or of
+// a country code given as is.
+func countryCode(country string) string {
+	name := strings.Join(normalizeWords(country), " ")
+
+	if code, ok := countryCodes[name]; ok {
+		return code
+	}
+
+	for _, code := range countryCodes {
+		if strings.EqualFold(code, strings.TrimSpace(country)) {
+			return code
+		}
+	}
+
+	return ""
+}
+
+var cityAbbreviations = map[string]string{
+	"ST":  "SAINT",
+	"STE": "SAINTE",
+}
+
+// normalizeCity returns city the way La Poste writes it: upper case without
+// accents nor punctuation, "ST" spelled out and without CEDEX suffix.
+func normalizeCity(city string) string {
+	words := normalizeWords(city)
+
+	for i, word := range words {
+		word = strings.ToUpper(word)
+
+		if word == "CEDEX" {
+			words = words[:i]
+			break
+		}
+
+		if expanded, ok := cityAbbreviations[word]; ok {
+			word = expanded
+		}
+
+		words[i] = word
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,109 @@
+package revtc
+
+import (
+	pb "github.com/united-drivers/go-revtc/proto"
+	"testing"
+)
+
+func TestDepartmentFromPostalCode(t *testing.T) {
+	tests := []struct {
+		postalCode string
+		want       string
+	}{
+		{"75002", "75"},
+		{"01000", "01"},
+		// Corse-du-Sud ends at 201xx, Haute-Corse starts at 202xx
+		{"20000", "2A"},
+		{"20190", "2A"},
+		{"20200", "2B"},
+		{"20600", "2B"},
+		{"97400", "974"},
+		// Saint-Barthélemy and Saint-Martin share the Guadeloupe prefix
+		{"97133", "977"},
+		{"97150", "978"},
+		{"97100", "971"},
+		{"98800", "988"},
+		{"00999", ""},
+		{"96000", ""},
+		{"7500", ""},
+		{"750002", ""},
+		{"75A02", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := departmentFromPostalCode(test.postalCode); got != test.want {
+			t.Errorf("departmentFromPostalCode(%q) = %q, want %q", test.postalCode, got, test.want)
+		}
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		in   pb.Address
+		want pb.Address
+	}{
+		{
+			"france",
+			pb.Address{City: "St Étienne Cedex 1", PostalCode: "42 000", Country: "France"},
+			pb.Address{City: "St Étienne Cedex 1", CityNormalized: "SAINT ETIENNE", PostalCode: "42000", PostalCodeValid: true, Country: "France", CountryCode: "FR", DepartmentCode: "42", DepartmentName: "Loire", Region: "Auvergne-Rhône-Alpes"},
+		},
+		{
+			"no country",
+			pb.Address{City: "Ajaccio", PostalCode: "20190"},
+			pb.Address{City: "Ajaccio", CityNormalized: "AJACCIO", PostalCode: "20190", PostalCodeValid: true, CountryCode: "FR", DepartmentCode: "2A", DepartmentName: "Corse-du-Sud", Region: "Corse"},
+		},
+		{
+			"haute-corse",
+			pb.Address{City: "Bastia", PostalCode: "20200", Country: "FRANCE"},
+			pb.Address{City: "Bastia", CityNormalized: "BASTIA", PostalCode: "20200", PostalCodeValid: true, Country: "FRANCE", CountryCode: "FR", DepartmentCode: "2B", DepartmentName: "Haute-Corse", Region: "Corse"},
+		},
+		{
+			"saint-barthelemy",
+			pb.Address{City: "Gustavia", PostalCode: "97133", Country: "Saint-Barthélemy"},
+			pb.Address{City: "Gustavia", CityNormalized: "GUSTAVIA", PostalCode: "97133", PostalCodeValid: true, Country: "Saint-Barthélemy", CountryCode: "BL", DepartmentCode: "977", DepartmentName: "Saint-Barthélemy"},
+		},
+		{
+			"saint-martin",
+			pb.Address{City: "Marigot", PostalCode: "97150"},
+			pb.Address{City: "Marigot", CityNormalized: "MARIGOT", PostalCode: "97150", PostalCodeValid: true, CountryCode: "FR", DepartmentCode: "978", DepartmentName: "Saint-Martin"},
+		},
+		{
+			"invalid postal code",
+			pb.Address{City: "Paris", PostalCode: "7500", Country: "France"},
+			pb.Address{City: "Paris", CityNormalized: "PARIS", PostalCode: "7500", Country: "France", CountryCode: "FR"},
+		},
+		{
+			"invalid postal code with department label",
+			pb.Address{City: "Lyon", PostalCode: "6900", Department: "69 - Rhône"},
+			pb.Address{City: "Lyon", CityNormalized: "LYON", PostalCode: "6900", Department: "69 - Rhône", CountryCode: "FR", DepartmentCode: "69", DepartmentName: "Rhône", Region: "Auvergne-Rhône-Alpes"},
+		},
+		{
+			"known foreign country",
+			pb.Address{City: "Kyiv", PostalCode: "01001", Country: "Ukraine"},
+			pb.Address{City: "Kyiv", CityNormalized: "KYIV", PostalCode: "01001", Country: "Ukraine", CountryCode: "UA"},
+		},
+		{
+			"foreign country short form",
+			pb.Address{City: "Moscou", PostalCode: "101000", Country: "Russie"},
+			pb.Address{City: "Moscou", CityNormalized: "MOSCOU", PostalCode: "101000", Country: "Russie", CountryCode: "RU"},
+		},
+		{
+			"unknown foreign country",
+			pb.Address{City: "Atlantis", PostalCode: "75002", Country: "Atlantide"},
+			pb.Address{City: "Atlantis", CityNormalized: "ATLANTIS", PostalCode: "75002", Country: "Atlantide"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.in
+			NormalizeAddress(&got)
+
+			if got.String() != test.want.String() {
+				t.Errorf("NormalizeAddress(%v) = %v, want %v", test.in.String(), got.String(), test.want.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package revtc
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of lookups of a batch run at once.
+const DefaultBatchConcurrency = 4
+
+// IdentifierKind tells which lookup an Identifier goes through.
+type IdentifierKind int
+
+const (
+	IdentifierCompanyNumber IdentifierKind = iota
+	IdentifierRegistrationNumber
+	IdentifierRecordId
+)
+
+func (k IdentifierKind) String() string {
+	switch k {
+	case IdentifierCompanyNumber:
+		return "company_number"
+	case IdentifierRegistrationNumber:
+		return "registration_number"
+	case IdentifierRecordId:
+		return "record_id"
+	}
+
+	return fmt.Sprintf("IdentifierKind(%d)", int(k))
+}
+
+// Identifier designates a single operator.
+type Identifier struct {
+	Kind  IdentifierKind
+	Value string
+}
+
+// ParseIdentifier guesses the kind of an identifier: values starting with
+// "EVTC" are registration numbers and 9 or 14 digits are a SIREN or a SIRET.
+// Record ids are never guessed, since a mistyped SIREN would otherwise fetch
+// an unrelated operator; callers build them explicitly.
+func ParseIdentifier(value string) (Identifier, error) {
+	compact := strings.ToUpper(separatorRemover.Replace(value))
+
+	switch {
+	case strings.HasPrefix(compact, "EVTC"):
+		return Identifier{Kind: IdentifierRegistrationNumber, Value: value}, nil
+	case digitsPattern.MatchString(compact) && (len(compact) == 9 || len(compact) == 14):
+		return Identifier{Kind: IdentifierCompanyNumber, Value: value}, nil
+	case digitsPattern.MatchString(compact):
+		return Identifier{}, &InvalidInputError{Field: "identifier", Reason: fmt.Sprintf("%q must be a 9 digit SIREN or a 14 digit SIRET, got %d digits", value, len(compact))}
+	}
+
+	return Identifier{}, &InvalidInputError{Field: "identifier", Reason: fmt.Sprintf("%q is not a SIREN, a SIRET or a registration number", value)}
+}
+
+// Lookup fetches the operator designated by id.
+func (c *Client) Lookup(ctx context.Context, id Identifier) (pb.VTCEntry, error) {
+	switch id.Kind {
+	case IdentifierCompanyNumber:
+		return c.GetByCompanyNumber(ctx, id.Value)
+	case IdentifierRegistrationNumber:
+		return c.GetByRegistrationNumber(ctx, id.Value)
+	case IdentifierRecordId:
+		recordId, err := strconv.Atoi(id.Value)
+
+		if err != nil || recordId < 1 {
+			return pb.VTCEntry{}, &InvalidInputError{Field: "record id", Reason: "must be a positive integer"}
+		}
+
+		return c.GetByRecordId(ctx, recordId)
+	}
+
+	return pb.VTCEntry{}, &InvalidInputError{Field: "identifier", Reason: fmt.Sprintf("unknown kind %d", int(id.Kind))}
+}
+
+// BatchResult is the outcome of the lookup of ids[Index] in a batch.
+type BatchResult struct {
+	Index int
+	Entry pb.VTCEntry
+	Err   error
+}
+
+// Batch looks every id up, running at most concurrency lookups at once (or
+// DefaultBatchConcurrency when concurrency is not positive). Results are sent
+// as they complete, so not in the order of ids; the channel is closed once
+// every id has been looked up and must be drained by the caller.
+//
+// The lookups still go through the client rate limit and concurrency cap, so
+// concurrency above WithMaxConcurrency only queues requests.
+func (c *Client) Batch(ctx context.Context, ids []Identifier, concurrency int) <-chan BatchResult {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	results := make(chan BatchResult)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency && i < len(ids); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				entry, err := c.Lookup(ctx, ids[index])
+				results <- BatchResult{Index: index, Entry: entry, Err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for index := range ids {
+			indexes <- index
+		}
+
+		close(indexes)
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
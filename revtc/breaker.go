@@ -0,0 +1,161 @@
+package revtc
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerThreshold is the number of consecutive failures that
+	// opens the circuit breaker.
+	DefaultBreakerThreshold = 5
+	// DefaultBreakerCooldown is how long the breaker stays open before
+	// letting a probe request through.
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// BreakerState is the state of the circuit breaker guarding the registry.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every request through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails every request with ErrRegistryUnavailable.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe request through.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// BreakerStatus is a snapshot of the circuit breaker.
+type BreakerStatus struct {
+	State               BreakerState
+	ConsecutiveFailures int
+	// OpenedAt is when the breaker last opened, zero if it never did.
+	OpenedAt time.Time
+}
+
+// WithCircuitBreaker makes the client fail fast with ErrRegistryUnavailable
+// once threshold consecutive requests to the registry failed, until a probe
+// sent after cooldown succeeds. A zero threshold disables the breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.breaker.threshold = threshold
+		c.breaker.cooldown = cooldown
+	}
+}
+
+// BreakerStatus reports the state of the circuit breaker.
+func (c *Client) BreakerStatus() BreakerStatus {
+	return c.breaker.status()
+}
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	// now is time.Now, unless replaced by tests
+	now func() time.Time
+}
+
+func (b *circuitBreaker) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+
+	return time.Now()
+}
+
+// allow reports whether a request may be sent now.
+func (b *circuitBreaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.clock().Sub(b.openedAt) >= b.cooldown {
+		b.state = BreakerHalfOpen
+	}
+
+	switch b.state {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+
+		b.probing = true
+	}
+
+	return true
+}
+
+// record feeds the outcome of a request allowed through.
+func (b *circuitBreaker) record(success bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if success {
+		b.state = BreakerClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.clock()
+	}
+}
+
+// release lets another probe through after a request whose outcome is not
+// recorded.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+func (b *circuitBreaker) status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := b.state
+
+	if state == BreakerOpen && b.clock().Sub(b.openedAt) >= b.cooldown {
+		state = BreakerHalfOpen
+	}
+
+	return BreakerStatus{
+		State:               state,
+		ConsecutiveFailures: b.failures,
+		OpenedAt:            b.openedAt,
+	}
+}
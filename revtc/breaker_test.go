@@ -0,0 +1,101 @@
+package revtc
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	b := &circuitBreaker{threshold: 3, cooldown: time.Minute, now: func() time.Time { return now }}
+
+	expect := func(step string, state BreakerState, allowed bool) {
+		t.Helper()
+
+		if got := b.status().State; got != state {
+			t.Errorf("%s: state %v, want %v", step, got, state)
+		}
+
+		if got := b.allow(); got != allowed {
+			t.Errorf("%s: allow() = %v, want %v", step, got, allowed)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		expect("below threshold", BreakerClosed, true)
+		b.record(false)
+	}
+
+	// a success resets the count
+	expect("below threshold", BreakerClosed, true)
+	b.record(true)
+
+	for i := 0; i < 3; i++ {
+		expect("failing", BreakerClosed, true)
+		b.record(false)
+	}
+
+	expect("threshold reached", BreakerOpen, false)
+
+	now = now.Add(59 * time.Second)
+	expect("cooling down", BreakerOpen, false)
+
+	now = now.Add(time.Second)
+	expect("probe", BreakerHalfOpen, true)
+	expect("while probing", BreakerHalfOpen, false)
+
+	// a failed probe opens the breaker for another cooldown
+	b.record(false)
+	expect("probe failed", BreakerOpen, false)
+
+	now = now.Add(time.Minute)
+	expect("second probe", BreakerHalfOpen, true)
+
+	// a probe whose outcome is not recorded lets another one through
+	b.release()
+	expect("probe released", BreakerHalfOpen, true)
+
+	b.record(true)
+	expect("probe succeeded", BreakerClosed, true)
+
+	if failures := b.status().ConsecutiveFailures; failures != 0 {
+		t.Errorf("%d consecutive failures after a success", failures)
+	}
+}
+
+// countingFetcher counts the requests it answers with status.
+type countingFetcher struct {
+	status   int
+	requests int32
+}
+
+func (f *countingFetcher) Do(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&f.requests, 1)
+
+	return statusFetcher(f.status).Do(req)
+}
+
+func TestCircuitBreakerFailsFast(t *testing.T) {
+	fetcher := &countingFetcher{status: http.StatusServiceUnavailable}
+	client := newFixtureClient(WithFetcher(fetcher), WithCircuitBreaker(2, time.Hour))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetByRecordId(ctx, 1234); err == ErrRegistryUnavailable {
+			t.Fatalf("lookup %d failed fast before the breaker opened", i+1)
+		}
+	}
+
+	if _, err := client.GetByRecordId(ctx, 1234); err != ErrRegistryUnavailable {
+		t.Errorf("lookup with the breaker open error = %v, want %v", err, ErrRegistryUnavailable)
+	}
+
+	if requests := atomic.LoadInt32(&fetcher.requests); requests != 2 {
+		t.Errorf("%d requests reached the registry, want 2", requests)
+	}
+
+	if state := client.BreakerStatus().State; state != BreakerOpen {
+		t.Errorf("breaker state %v, want %v", state, BreakerOpen)
+	}
+}
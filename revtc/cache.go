@@ -0,0 +1,144 @@
+package revtc
+
+import (
+	"context"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached entries are served without going back
+// to the registry.
+const DefaultCacheTTL = 24 * time.Hour
+
+// CacheItem is a cached lookup result.
+type CacheItem struct {
+	// Value is the protobuf encoding of a VTCEntry.
+	Value    []byte
+	StoredAt time.Time
+}
+
+// Cache stores lookup results in front of the registry. Implementations must
+// be safe for concurrent use; freshness is decided by the Client.
+type Cache interface {
+	Get(key string) (CacheItem, bool)
+	Set(key string, item CacheItem)
+}
+
+// WithCache serves GetByRecordId and GetByAdvancedSearch results from cache
+// for ttl after they were fetched.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// WithStaleWhileRevalidate keeps serving cached entries for up to stale past
+// their TTL, refreshing them in the background.
+func WithStaleWhileRevalidate(stale time.Duration) Option {
+	return func(c *Client) {
+		c.cacheStale = stale
+	}
+}
+
+type entryLoader func(ctx context.Context) (pb.VTCEntry, error)
+
+func recordCacheKey(recordId int) string {
+	return fmt.Sprintf("record:%d", recordId)
+}
+
+func searchCacheKey(params map[APISearchParams]string) string {
+	values := url.Values{}
+
+	for param, value := range params {
+		if value != "" {
+			values.Set(strconv.Itoa(int(param)), value)
+		}
+	}
+
+	return "search:" + values.Encode()
+}
+
+// cached returns the entry for key, from cache or through load, with its
+// validity status computed as of now.
+func (c *Client) cached(ctx context.Context, key string, load entryLoader) (pb.VTCEntry, error) {
+	entry, err := c.cachedEntry(ctx, key, load)
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	c.setValidityStatus(&entry)
+
+	return entry, nil
+}
+
+// cachedEntry returns the entry stored under key when it is fresh enough and
+// calls load otherwise. Entries between their TTL and the stale window are
+// returned as is while load runs in the background.
+func (c *Client) cachedEntry(ctx context.Context, key string, load entryLoader) (pb.VTCEntry, error) {
+	if c.cache != nil {
+		if item, ok := c.cache.Get(key); ok {
+			age := time.Since(item.StoredAt)
+
+			if age < c.cacheTTL+c.cacheStale {
+				var entry pb.VTCEntry
+
+				if err := proto.Unmarshal(item.Value, &entry); err == nil {
+					if age >= c.cacheTTL {
+						c.revalidate(key, load)
+					}
+
+					entry.FromCache = true
+
+					return entry, nil
+				}
+			}
+		}
+	}
+
+	return c.load(ctx, key, load)
+}
+
+func (c *Client) load(ctx context.Context, key string, load entryLoader) (pb.VTCEntry, error) {
+	entry, err := load(ctx)
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	now := time.Now()
+	entry.FetchedAt, _ = ptypes.TimestampProto(now)
+
+	if c.cache != nil {
+		if value, err := proto.Marshal(&entry); err == nil {
+			c.cache.Set(key, CacheItem{Value: value, StoredAt: now})
+		}
+	}
+
+	return entry, nil
+}
+
+func (c *Client) revalidate(key string, load entryLoader) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.revalidating[key] {
+		return
+	}
+
+	c.revalidating[key] = true
+
+	go func() {
+		c.load(context.Background(), key, load)
+
+		c.mu.Lock()
+		delete(c.revalidating, key)
+		c.mu.Unlock()
+	}()
+}
@@ -0,0 +1,126 @@
+package revtc
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileCacheSweepInterval is how often a FileCache looks for expired items
+// while items are being stored.
+const fileCacheSweepInterval = time.Hour
+
+// FileCache is a Cache storing one file per item in a directory, so that
+// cached entries survive restarts. The file modification time records when
+// the item was stored.
+type FileCache struct {
+	dir    string
+	maxAge time.Duration
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewFileCache returns a FileCache writing to dir, creating it if needed.
+// Items older than maxAge, which should cover the cache TTL and stale
+// window, are deleted when read and by an hourly sweep of dir run while
+// items are stored. A zero maxAge keeps them forever.
+func NewFileCache(dir string, maxAge time.Duration) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &FileCache{dir: dir, maxAge: maxAge, lastSweep: time.Now()}, nil
+}
+
+func (c *FileCache) expired(storedAt time.Time, now time.Time) bool {
+	return c.maxAge > 0 && now.Sub(storedAt) >= c.maxAge
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha1.Sum([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *FileCache) Get(key string) (CacheItem, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return CacheItem{}, false
+	}
+
+	if c.expired(info.ModTime(), time.Now()) {
+		os.Remove(path)
+
+		return CacheItem{}, false
+	}
+
+	value, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return CacheItem{}, false
+	}
+
+	return CacheItem{Value: value, StoredAt: info.ModTime()}, true
+}
+
+func (c *FileCache) Set(key string, item CacheItem) {
+	tmp, err := ioutil.TempFile(c.dir, ".tmp-")
+
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(item.Value)
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chtimes(tmp.Name(), item.StoredAt, item.StoredAt)
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+
+	c.mu.Lock()
+	due := c.maxAge > 0 && time.Since(c.lastSweep) >= fileCacheSweepInterval
+
+	if due {
+		c.lastSweep = time.Now()
+	}
+
+	c.mu.Unlock()
+
+	if due {
+		go c.sweep(time.Now())
+	}
+}
+
+// sweep deletes the items, and the temporary files left by interrupted
+// writes, that expired as of now.
+func (c *FileCache) sweep(now time.Time) {
+	files, err := ioutil.ReadDir(c.dir)
+
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		if !file.IsDir() && c.expired(file.ModTime(), now) {
+			os.Remove(filepath.Join(c.dir, file.Name()))
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package revtc
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRUCache is an in-memory Cache holding at most a fixed number of items,
+// evicting the least recently used ones first.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type lruItem struct {
+	key  string
+	item CacheItem
+}
+
+// NewLRUCache returns an LRUCache holding up to capacity items.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		items:    map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+func (c *LRUCache) Get(key string) (CacheItem, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elt, ok := c.items[key]
+
+	if !ok {
+		return CacheItem{}, false
+	}
+
+	c.order.MoveToFront(elt)
+
+	return elt.Value.(*lruItem).item, true
+}
+
+func (c *LRUCache) Set(key string, item CacheItem) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elt, ok := c.items[key]; ok {
+		elt.Value.(*lruItem).item = item
+		c.order.MoveToFront(elt)
+
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruItem{key: key, item: item})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruItem).key)
+	}
+}
@@ -0,0 +1,154 @@
+package revtc
+
+import (
+	"context"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", CacheItem{Value: []byte("a")})
+	cache.Set("b", CacheItem{Value: []byte("b")})
+
+	// reading a makes b the least recently used
+	cache.Get("a")
+	cache.Set("c", CacheItem{Value: []byte("c")})
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := cache.Get(key); ok != want {
+			t.Errorf("Get(%q) found = %v, want %v", key, ok, want)
+		}
+	}
+
+	// updating an item also refreshes it
+	cache.Set("a", CacheItem{Value: []byte("a2")})
+	cache.Set("d", CacheItem{Value: []byte("d")})
+
+	if item, ok := cache.Get("a"); !ok || string(item.Value) != "a2" {
+		t.Errorf("Get(\"a\") = %q, %v", item.Value, ok)
+	}
+
+	if _, ok := cache.Get("c"); ok {
+		t.Error("c was not evicted")
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "revtc-cache")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	cache, err := NewFileCache(dir, time.Hour)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	cache.Set("record:1234", CacheItem{Value: []byte("entry"), StoredAt: storedAt})
+
+	item, ok := cache.Get("record:1234")
+
+	if !ok || string(item.Value) != "entry" || !item.StoredAt.Equal(storedAt) {
+		t.Errorf("Get = %q stored at %v, %v; want %q stored at %v", item.Value, item.StoredAt, ok, "entry", storedAt)
+	}
+
+	if _, ok := cache.Get("record:5678"); ok {
+		t.Error("found an item never stored")
+	}
+
+	// items past maxAge are deleted when read
+	cache.Set("record:5678", CacheItem{Value: []byte("old"), StoredAt: time.Now().Add(-2 * time.Hour)})
+
+	if _, ok := cache.Get("record:5678"); ok {
+		t.Error("expired item served")
+	}
+
+	if _, err := os.Stat(cache.path("record:5678")); !os.IsNotExist(err) {
+		t.Errorf("expired item still on disk: %v", err)
+	}
+
+	// and by the sweep, with the leftovers of interrupted writes
+	cache.Set("search:1=732829320", CacheItem{Value: []byte("old"), StoredAt: time.Now().Add(-2 * time.Hour)})
+	leftover := filepath.Join(dir, ".tmp-leftover")
+	ioutil.WriteFile(leftover, nil, 0644)
+	os.Chtimes(leftover, time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour))
+
+	cache.sweep(time.Now())
+
+	files, _ := ioutil.ReadDir(dir)
+
+	if len(files) != 1 || files[0].Name() != filepath.Base(cache.path("record:1234")) {
+		t.Errorf("%d files left after the sweep, want only the fresh item", len(files))
+	}
+}
+
+// countingLoader loads a fresh copy of entry, counting its calls.
+func countingLoader(calls *int32, value string) entryLoader {
+	return func(ctx context.Context) (pb.VTCEntry, error) {
+		atomic.AddInt32(calls, 1)
+
+		return pb.VTCEntry{RegistrationNumber: value}, nil
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	cache := NewLRUCache(10)
+	client := newFixtureClient(WithCache(cache, time.Hour), WithStaleWhileRevalidate(time.Hour))
+
+	var calls int32
+
+	store := func(age time.Duration) {
+		value, _ := proto.Marshal(&pb.VTCEntry{RegistrationNumber: "cached"})
+		cache.Set("key", CacheItem{Value: value, StoredAt: time.Now().Add(-age)})
+	}
+
+	// fresh entries are served without loading
+	store(time.Minute)
+	entry, err := client.cached(ctx, "key", countingLoader(&calls, "loaded"))
+
+	if err != nil || entry.RegistrationNumber != "cached" || !entry.FromCache || atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("fresh: got %q (from cache %v), %d loads, error %v", entry.RegistrationNumber, entry.FromCache, calls, err)
+	}
+
+	// stale entries are served while being refreshed in the background
+	store(90 * time.Minute)
+	entry, err = client.cached(ctx, "key", countingLoader(&calls, "loaded"))
+
+	if err != nil || entry.RegistrationNumber != "cached" || !entry.FromCache {
+		t.Errorf("stale: got %q (from cache %v), error %v", entry.RegistrationNumber, entry.FromCache, err)
+	}
+
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if item, _ := cache.Get("key"); time.Since(item.StoredAt) < time.Minute {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("stale entry not revalidated")
+		}
+	}
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("stale: %d loads, want 1", calls)
+	}
+
+	// past the TTL and stale window, entries are loaded again right away
+	store(3 * time.Hour)
+	entry, err = client.cached(ctx, "key", countingLoader(&calls, "loaded"))
+
+	if err != nil || entry.RegistrationNumber != "loaded" || entry.FromCache || atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("expired: got %q (from cache %v), %d loads, error %v", entry.RegistrationNumber, entry.FromCache, calls, err)
+	}
+}
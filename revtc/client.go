@@ -0,0 +1,227 @@
+// Package revtc fetches operator records from the French VTC registry
+// published at https://registre-vtc.developpement-durable.gouv.fr.
+package revtc
+
+import (
+	"context"
+	"golang.org/x/net/html"
+	"golang.org/x/time/rate"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL is the public entry point of the registry.
+const DefaultBaseURL = "https://registre-vtc.developpement-durable.gouv.fr/public"
+
+// DefaultTimeout bounds each request sent to the registry.
+const DefaultTimeout = 30 * time.Second
+
+// Client performs lookups against the registry.
+type Client struct {
+	baseURL string
+	fetcher Fetcher
+	timeout time.Duration
+
+	cache      Cache
+	cacheTTL   time.Duration
+	cacheStale time.Duration
+
+	rateLimit      float64
+	rateBurst      int
+	maxConcurrency int
+	maxWait        time.Duration
+	limiter        *rate.Limiter
+	slots          chan struct{}
+
+	maxAttempts   int
+	retryDelay    time.Duration
+	retryMaxDelay time.Duration
+
+	breaker circuitBreaker
+
+	expiryWarning time.Duration
+
+	parser parserHealth
+
+	mu           sync.Mutex
+	revalidating map[string]bool
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL overrides the registry base URL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to reach the registry.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return WithFetcher(httpClient)
+}
+
+// WithTimeout bounds each request sent to the registry, on top of any
+// deadline carried by the lookup context. Zero disables the bound.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// NewClient returns a Client talking to DefaultBaseURL with
+// http.DefaultClient, unless overridden by opts.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:  DefaultBaseURL,
+		fetcher:  http.DefaultClient,
+		timeout:  DefaultTimeout,
+		cacheTTL: DefaultCacheTTL,
+
+		rateLimit:      DefaultRateLimit,
+		rateBurst:      DefaultRateBurst,
+		maxConcurrency: DefaultMaxConcurrency,
+		maxWait:        DefaultMaxWait,
+
+		maxAttempts:   DefaultMaxAttempts,
+		retryDelay:    DefaultRetryDelay,
+		retryMaxDelay: DefaultRetryMaxDelay,
+
+		expiryWarning: DefaultExpiryWarning,
+
+		breaker: circuitBreaker{
+			threshold: DefaultBreakerThreshold,
+			cooldown:  DefaultBreakerCooldown,
+		},
+
+		parser: newParserHealth(),
+
+		revalidating: map[string]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.initLimits()
+
+	return c
+}
+
+func (c *Client) get(ctx context.Context, requestUrl string) (*html.Node, error) {
+	req, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.fetch(ctx, req)
+}
+
+func (c *Client) postForm(ctx context.Context, requestUrl string, data url.Values) (*html.Node, error) {
+	req, err := http.NewRequest(http.MethodPost, requestUrl, strings.NewReader(data.Encode()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.fetch(ctx, req)
+}
+
+// fetch sends req and parses the page it returns, retrying transient
+// failures. Every request to the registry goes through here.
+func (c *Client) fetch(ctx context.Context, req *http.Request) (*html.Node, error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			req.Body = body
+		}
+
+		doc, err := c.fetchOnce(ctx, req)
+		upstreamErr, ok := err.(*UpstreamError)
+
+		if !ok {
+			return doc, err
+		}
+
+		upstreamErr.Attempts = attempt
+
+		// the lookup ran out of time or was abandoned, whatever the registry did
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if attempt >= c.maxAttempts || !upstreamErr.temporary() {
+			return nil, upstreamErr
+		}
+
+		delay, ok := c.backoff(attempt, upstreamErr.RetryAfter)
+
+		if !ok {
+			return nil, upstreamErr
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// fetchOnce waits for the rate limits then sends req once, unless the
+// circuit breaker is open.
+func (c *Client) fetchOnce(ctx context.Context, req *http.Request) (*html.Node, error) {
+	release, err := c.acquire(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	if !c.breaker.allow() {
+		return nil, ErrRegistryUnavailable
+	}
+
+	doc, err := c.send(ctx, req)
+	upstreamErr, ok := err.(*UpstreamError)
+
+	// a request cancelled by its caller says nothing about the registry
+	if ok && ctx.Err() != nil {
+		c.breaker.release()
+	} else {
+		c.breaker.record(!ok || !upstreamErr.temporary())
+	}
+
+	return doc, err
+}
+
+func (c *Client) send(ctx context.Context, req *http.Request) (*html.Node, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	resp, err := c.fetcher.Do(req.WithContext(ctx))
+
+	if err != nil {
+		return nil, &UpstreamError{Err: err}
+	}
+
+	defer resp.Body.Close()
+
+	return parsePage(resp)
+}
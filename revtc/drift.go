@@ -0,0 +1,309 @@
+package revtc
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/andybalholm/cascadia"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"golang.org/x/net/html"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxDriftSamples caps the pages logged over the life of a client, each
+	// of them showing a different kind of drift.
+	maxDriftSamples = 50
+	// maxTrackedLabels caps the distinct labels counted in ParserHealth;
+	// further ones are counted under otherLabels.
+	maxTrackedLabels = 100
+	otherLabels      = "(other)"
+	// driftExcerptSize is how much of a page is logged when samples are not
+	// saved to files.
+	driftExcerptSize = 2000
+)
+
+var noResultPattern = regexp.MustCompile(`(?i)aucun(?:e)?\s+(?:élément|résultat|exploitant|dossier)s?\s+(?:n'a\s+été\s+)?trouvée?s?|introuvable`)
+
+// labels every detail page carries, whatever the operator
+var expectedLabels = []string{lCompanyNumber, lRegistrationNumber, lExpirationDate, lLegalEntityType}
+
+var expectedLabelsByType = map[pb.LEGAL_ENTITY_TYPE][]string{
+	pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_COMPANY:    {lCompanyName},
+	pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_INDIVIDUAL: {lIndividualLastName},
+}
+
+type pageKind int
+
+const (
+	pageUnrecognised pageKind = iota
+	pageDetail
+	pageResultList
+	pageNoResult
+)
+
+func (k pageKind) String() string {
+	switch k {
+	case pageDetail:
+		return "detail"
+	case pageResultList:
+		return "result list"
+	case pageNoResult:
+		return "no result"
+	}
+
+	return "unrecognised"
+}
+
+// pageReport tells how a registry page matched the layout the parser
+// expects.
+type pageReport struct {
+	kind          pageKind
+	unknownLabels []string
+	missingLabels []string
+}
+
+// drifted tells whether the page no longer matches the layout. Unknown
+// labels alone are not drift: they are kept in VTCEntry.RawFields.
+func (r pageReport) drifted() bool {
+	return r.kind == pageUnrecognised || len(r.missingLabels) > 0
+}
+
+// signature identifies the kind of drift, so that it is only sampled once.
+func (r pageReport) signature() string {
+	return fmt.Sprintf("%s|%s|%s", r.kind, strings.Join(r.unknownLabels, ","), strings.Join(r.missingLabels, ","))
+}
+
+func (r pageReport) String() string {
+	if r.kind != pageDetail {
+		return r.kind.String() + " page"
+	}
+
+	var parts []string
+
+	if len(r.missingLabels) > 0 {
+		parts = append(parts, fmt.Sprintf("missing labels %q", r.missingLabels))
+	}
+
+	if len(r.unknownLabels) > 0 {
+		parts = append(parts, fmt.Sprintf("unknown labels %q", r.unknownLabels))
+	}
+
+	return "detail page with " + strings.Join(parts, " and ")
+}
+
+// inspectLabels compares the labels read from a page to the ones the parser
+// knows. A page without any known label is not a detail page.
+func inspectLabels(mapped map[string]string) pageReport {
+	report := pageReport{kind: pageUnrecognised}
+
+	for label := range mapped {
+		if mappedLabels[label] {
+			report.kind = pageDetail
+		} else if label != "" {
+			report.unknownLabels = append(report.unknownLabels, label)
+		}
+	}
+
+	sort.Strings(report.unknownLabels)
+
+	if report.kind != pageDetail {
+		return report
+	}
+
+	expected := append([]string{}, expectedLabels...)
+	expected = append(expected, expectedLabelsByType[castAPILegalEntityType(mapped[lLegalEntityType])]...)
+
+	for _, label := range expected {
+		if _, ok := mapped[label]; !ok {
+			report.missingLabels = append(report.missingLabels, label)
+		}
+	}
+
+	return report
+}
+
+// classifyPage tells apart pages saying nothing was found and result lists
+// from pages the parser does not recognise.
+func classifyPage(doc *html.Node) pageKind {
+	text := getText(doc)
+
+	if noResultPattern.MatchString(text) {
+		return pageNoResult
+	}
+
+	sel, errCss := cascadia.Compile(".pagebanner, .pagelinks, table.displaytag")
+
+	if errCss == nil && sel.MatchFirst(doc) != nil {
+		return pageResultList
+	}
+
+	if totalCountPattern.MatchString(text) {
+		return pageResultList
+	}
+
+	return pageUnrecognised
+}
+
+// ParserHealth counts how the registry pages read by a client matched the
+// layout the parser expects. Drift pages are unrecognised pages and detail
+// pages missing expected labels; unknown labels are counted on their own.
+type ParserHealth struct {
+	DetailPages       int64            `json:"detail_pages"`
+	ResultListPages   int64            `json:"result_list_pages"`
+	NoResultPages     int64            `json:"no_result_pages"`
+	UnrecognisedPages int64            `json:"unrecognised_pages"`
+	DriftPages        int64            `json:"drift_pages"`
+	UnknownLabels     map[string]int64 `json:"unknown_labels"`
+	MissingLabels     map[string]int64 `json:"missing_labels"`
+	LastDriftAt       time.Time        `json:"last_drift_at"`
+}
+
+// WithDriftLog sets where layout drift is reported: a line is written to
+// logger the first time each kind of drift is seen, and the page is saved
+// in sampleDir when it is set, or excerpted in the log otherwise. A nil
+// logger disables the reports.
+func WithDriftLog(logger *log.Logger, sampleDir string) Option {
+	return func(c *Client) {
+		c.parser.logger = logger
+		c.parser.sampleDir = sampleDir
+	}
+}
+
+// ParserHealth returns the layout counters of the pages read so far.
+func (c *Client) ParserHealth() ParserHealth {
+	return c.parser.snapshot()
+}
+
+// inspectPage parses a page like handleSingleResultPage, recording how it
+// matched the expected layout.
+func (c *Client) inspectPage(doc *html.Node) (pb.VTCEntry, error) {
+	entry, report, err := inspectSingleResultPage(doc)
+	c.parser.record(report, doc)
+
+	return entry, err
+}
+
+type parserHealth struct {
+	mu        sync.Mutex
+	stats     ParserHealth
+	samples   map[string]bool
+	logger    *log.Logger
+	sampleDir string
+}
+
+func newParserHealth() parserHealth {
+	return parserHealth{
+		stats: ParserHealth{
+			UnknownLabels: map[string]int64{},
+			MissingLabels: map[string]int64{},
+		},
+		samples: map[string]bool{},
+		logger:  log.New(os.Stderr, "revtc: ", log.LstdFlags),
+	}
+}
+
+func countLabel(counts map[string]int64, label string) {
+	if _, ok := counts[label]; !ok && len(counts) >= maxTrackedLabels {
+		label = otherLabels
+	}
+
+	counts[label]++
+}
+
+func (h *parserHealth) record(report pageReport, doc *html.Node) {
+	h.mu.Lock()
+
+	switch report.kind {
+	case pageDetail:
+		h.stats.DetailPages++
+	case pageResultList:
+		h.stats.ResultListPages++
+	case pageNoResult:
+		h.stats.NoResultPages++
+	default:
+		h.stats.UnrecognisedPages++
+	}
+
+	for _, label := range report.unknownLabels {
+		countLabel(h.stats.UnknownLabels, label)
+	}
+
+	for _, label := range report.missingLabels {
+		countLabel(h.stats.MissingLabels, label)
+	}
+
+	sample := false
+
+	if report.drifted() {
+		h.stats.DriftPages++
+		h.stats.LastDriftAt = time.Now()
+
+		signature := report.signature()
+
+		if !h.samples[signature] && len(h.samples) < maxDriftSamples {
+			h.samples[signature] = true
+			sample = h.logger != nil
+		}
+	}
+
+	h.mu.Unlock()
+
+	if sample {
+		h.logSample(report, doc)
+	}
+}
+
+func (h *parserHealth) logSample(report pageReport, doc *html.Node) {
+	var page bytes.Buffer
+
+	if err := html.Render(&page, doc); err != nil {
+		h.logger.Printf("layout drift: %s, page cannot be rendered: %v", report, err)
+
+		return
+	}
+
+	if h.sampleDir != "" {
+		path := filepath.Join(h.sampleDir, fmt.Sprintf("layout-drift-%d.html", time.Now().UnixNano()))
+
+		if err := ioutil.WriteFile(path, page.Bytes(), 0644); err == nil {
+			h.logger.Printf("layout drift: %s, sample saved to %s", report, path)
+
+			return
+		}
+	}
+
+	excerpt := page.String()
+
+	if len(excerpt) > driftExcerptSize {
+		excerpt = excerpt[:driftExcerptSize] + "..."
+	}
+
+	h.logger.Printf("layout drift: %s, sample page:\n%s", report, excerpt)
+}
+
+func (h *parserHealth) snapshot() ParserHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := h.stats
+	stats.UnknownLabels = map[string]int64{}
+	stats.MissingLabels = map[string]int64{}
+
+	for label, count := range h.stats.UnknownLabels {
+		stats.UnknownLabels[label] = count
+	}
+
+	for label, count := range h.stats.MissingLabels {
+		stats.MissingLabels[label] = count
+	}
+
+	return stats
+}
@@ -0,0 +1,121 @@
+package revtc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParserHealth(t *testing.T) {
+	var logs bytes.Buffer
+
+	client := newFixtureClient(WithDriftLog(log.New(&logs, "", 0), ""))
+
+	if _, err := client.GetByRecordId(ctx, 1234); err != nil {
+		t.Fatal(err)
+	}
+
+	// an unknown label is kept in raw_fields, the layout is still fine
+	if health := client.ParserHealth(); health.DriftPages != 0 || !health.LastDriftAt.IsZero() {
+		t.Errorf("detail page with an unknown label counted as drift: %+v", health)
+	}
+
+	_, err := client.GetByAdvancedSearch(ctx, map[APISearchParams]string{SearchCompanyName: "INCONNU"})
+
+	if err != ErrNotFound {
+		t.Fatalf("error = %v, want ErrNotFound", err)
+	}
+
+	// two result list pages, then the detail page of each of the 3 results
+	if _, err := client.Search(ctx, map[APISearchParams]string{SearchCity: "Lyon"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the SIREN label of this page was renamed, twice
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetByCompanyNumber(ctx, "542065479"); err == nil {
+			t.Fatal("renamed SIREN label parsed")
+		}
+	}
+
+	health := client.ParserHealth()
+
+	checks := []struct {
+		field string
+		got   int64
+		want  int64
+	}{
+		{"detail pages", health.DetailPages, 6},
+		{"result list pages", health.ResultListPages, 2},
+		{"no result pages", health.NoResultPages, 1},
+		{"unrecognised pages", health.UnrecognisedPages, 0},
+		{"drift pages", health.DriftPages, 2},
+		{"unknown SIREN label", health.UnknownLabels["SIREN"], 2},
+		{"unknown certificate label", health.UnknownLabels["Attestation de capacité"], 4},
+		{"missing SIREN label", health.MissingLabels[lCompanyNumber], 2},
+	}
+
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %d, want %d", check.field, check.got, check.want)
+		}
+	}
+
+	if health.LastDriftAt.IsZero() {
+		t.Error("last drift time not set")
+	}
+
+	// the renamed label is logged once, the extra label is not drift
+	if count := strings.Count(logs.String(), "layout drift:"); count != 1 {
+		t.Errorf("%d drift samples logged, want 1:\n%s", count, logs.String())
+	}
+}
+
+func TestSearchUnrecognisedPage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "revtc")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	page, err := ioutil.ReadFile(filepath.Join("testdata", "golden", "renamed_label_class.html"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := "rechercheExploitant.avancee.action_numeroSiren_732829320.html"
+
+	if err := ioutil.WriteFile(filepath.Join(dir, name), page, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+
+	client := newFixtureClient(
+		WithFetcher(FixtureFetcher{Dir: dir}),
+		WithDriftLog(log.New(&logs, "", 0), dir),
+	)
+
+	_, err = client.Search(ctx, map[APISearchParams]string{SearchCompanyNumber: "732829320"})
+
+	if _, ok := err.(*LayoutError); !ok {
+		t.Errorf("error = %v, want a LayoutError", err)
+	}
+
+	if health := client.ParserHealth(); health.UnrecognisedPages != 1 || health.DriftPages != 1 {
+		t.Errorf("health = %+v", health)
+	}
+
+	samples, _ := filepath.Glob(filepath.Join(dir, "layout-drift-*.html"))
+
+	if len(samples) != 1 || !strings.Contains(logs.String(), samples[0]) {
+		t.Errorf("samples = %v, logs = %q", samples, logs.String())
+	}
+}
@@ -0,0 +1,90 @@
+package revtc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrNotFound is returned when the registry has no entry for a lookup.
+var ErrNotFound = errors.New("not found")
+
+// ErrAmbiguous is returned when a lookup expected to resolve to a single
+// operator matches several of them; Search returns them all.
+var ErrAmbiguous = errors.New("several operators match")
+
+// errResultList is returned for result list pages by
+// handleSingleResultPage, whose callers resolve the rows of the list.
+var errResultList = errors.New("result list")
+
+// ErrPageOutOfRange is returned when a result list page does not exist.
+var ErrPageOutOfRange = errors.New("page out of range")
+
+// ErrRateLimited is returned when a request waited too long for its turn
+// under the client rate limit or concurrency cap.
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrRegistryUnavailable is returned without contacting the registry while
+// the circuit breaker is open after repeated failures.
+var ErrRegistryUnavailable = errors.New("registry unavailable")
+
+// UpstreamError reports that the registry could not be reached or answered
+// with an unexpected HTTP status.
+type UpstreamError struct {
+	// StatusCode is the HTTP status returned by the registry, or 0 when no
+	// response was received.
+	StatusCode int
+	// Err is the transport error, if any.
+	Err error
+	// RetryAfter is the delay requested by the registry through the
+	// Retry-After header.
+	RetryAfter time.Duration
+	// Attempts is the number of requests made before giving up.
+	Attempts int
+}
+
+func (e *UpstreamError) Error() string {
+	var msg string
+
+	if e.Err != nil {
+		msg = fmt.Sprintf("registry unreachable: %v", e.Err)
+	} else {
+		msg = fmt.Sprintf("registry answered with status %d", e.StatusCode)
+	}
+
+	if e.Attempts > 1 {
+		msg += fmt.Sprintf(" (after %d attempts)", e.Attempts)
+	}
+
+	return msg
+}
+
+// temporary reports whether the request may succeed if sent again.
+func (e *UpstreamError) temporary() bool {
+	if e.StatusCode == 0 {
+		return true
+	}
+
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// LayoutError reports a registry page that could not be parsed.
+type LayoutError struct {
+	Reason string
+}
+
+func (e *LayoutError) Error() string {
+	return fmt.Sprintf("unexpected registry page layout: %s", e.Reason)
+}
+
+// InvalidInputError reports lookup input rejected before reaching the
+// registry.
+type InvalidInputError struct {
+	Field  string
+	Reason string
+}
+
+func (e *InvalidInputError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
@@ -0,0 +1,162 @@
+package revtc
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Fetcher sends requests to the registry. *http.Client is a Fetcher; other
+// implementations can serve pages from elsewhere, such as FixtureFetcher.
+type Fetcher interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WithFetcher sets how requests reach the registry, replacing the HTTP
+// client.
+func WithFetcher(fetcher Fetcher) Option {
+	return func(c *Client) {
+		c.fetcher = fetcher
+	}
+}
+
+var fixtureNameReplacer = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// FixtureName returns the file name under which the page answering req is
+// recorded: the last segment of the URL path followed by the non-empty
+// query and form values, sorted by key. Search form fields are shortened to
+// their criterion name, so that a search by SIREN is recorded as
+// "rechercheExploitant.avancee.action_numeroSiren_732829320.html".
+func FixtureName(req *http.Request) (string, error) {
+	values := url.Values{}
+
+	for key, value := range req.URL.Query() {
+		values[key] = value
+	}
+
+	if req.Body != nil && req.Method == http.MethodPost {
+		body, err := ioutil.ReadAll(req.Body)
+
+		if err != nil {
+			return "", err
+		}
+
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		form, err := url.ParseQuery(string(body))
+
+		if err != nil {
+			return "", err
+		}
+
+		for key, value := range form {
+			values[key] = append(values[key], value...)
+		}
+	}
+
+	var parts []string
+
+	for key, value := range values {
+		// the submit button is sent with every search
+		if strings.HasPrefix(key, "action:") || len(value) == 0 || value[0] == "" {
+			continue
+		}
+
+		key = strings.TrimPrefix(key, "rechercheCriteres.")
+		parts = append(parts, key+"_"+strings.Join(value, "_"))
+	}
+
+	sort.Strings(parts)
+
+	name := path.Base(req.URL.Path)
+
+	if len(parts) > 0 {
+		name += "_" + strings.Join(parts, "_")
+	}
+
+	return fixtureNameReplacer.ReplaceAllString(name, "_") + ".html", nil
+}
+
+// FixtureFetcher replays registry pages saved in a directory, as named by
+// FixtureName, and fails on requests it has no page for. It lets the client
+// run without reaching the registry, in tests or demos.
+type FixtureFetcher struct {
+	Dir string
+}
+
+// Do answers req with its recorded page.
+func (f FixtureFetcher) Do(req *http.Request) (*http.Response, error) {
+	name, err := FixtureName(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := ioutil.ReadFile(filepath.Join(f.Dir, name))
+
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no fixture %s for %s %s", name, req.Method, req.URL)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"text/html;charset=UTF-8"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(page)),
+		ContentLength: int64(len(page)),
+		Request:       req,
+	}, nil
+}
+
+// RecordingFetcher passes requests on to Fetcher and saves the successful
+// responses in Dir, named by FixtureName, for FixtureFetcher to replay.
+type RecordingFetcher struct {
+	Fetcher Fetcher
+	Dir     string
+}
+
+// Do sends req and records the page it returns.
+func (f RecordingFetcher) Do(req *http.Request) (*http.Response, error) {
+	name, err := FixtureName(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := f.Fetcher.Do(req)
+
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	page, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(page))
+
+	if err := ioutil.WriteFile(filepath.Join(f.Dir, name), page, 0644); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,108 @@
+package revtc
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFixtureName(t *testing.T) {
+	search, _ := http.NewRequest(http.MethodPost, DefaultBaseURL+"/rechercheExploitant.avancee.action", strings.NewReader(url.Values{
+		"rechercheCriteres.numeroSiren":                    {"732829320"},
+		"rechercheCriteres.ville":                          {""},
+		"action:/public/rechercheExploitant.liste.avancee": {"Rechercher"},
+	}.Encode()))
+	detail, _ := http.NewRequest(http.MethodGet, DefaultBaseURL+"/rechercheExploitant.exploitantDetails.action?dossier.id=1234", nil)
+	page, _ := http.NewRequest(http.MethodGet, DefaultBaseURL+"/rechercheExploitant.liste.avancee.action?rechercheCriteres.ville=Saint+%C3%89tienne&d-49489-p=2", nil)
+
+	tests := []struct {
+		req  *http.Request
+		want string
+	}{
+		{search, "rechercheExploitant.avancee.action_numeroSiren_732829320.html"},
+		{detail, "rechercheExploitant.exploitantDetails.action_dossier.id_1234.html"},
+		{page, "rechercheExploitant.liste.avancee.action_d-49489-p_2_ville_Saint_tienne.html"},
+	}
+
+	for _, test := range tests {
+		got, err := FixtureName(test.req)
+
+		if err != nil {
+			t.Fatalf("FixtureName(%s): %v", test.req.URL, err)
+		}
+
+		if got != test.want {
+			t.Errorf("FixtureName(%s) = %q, want %q", test.req.URL, got, test.want)
+		}
+	}
+}
+
+func TestFixtureNameKeepsBody(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, DefaultBaseURL+"/rechercheExploitant.avancee.action", strings.NewReader("rechercheCriteres.sigle=TDP"))
+
+	if _, err := FixtureName(req); err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := ioutil.ReadAll(req.Body)
+
+	if string(body) != "rechercheCriteres.sigle=TDP" {
+		t.Errorf("body after FixtureName = %q", body)
+	}
+}
+
+func TestFixtureFetcherMissingPage(t *testing.T) {
+	client := newFixtureClient()
+
+	_, err := client.GetByRecordId(ctx, 42)
+	upstreamErr, ok := err.(*UpstreamError)
+
+	if !ok {
+		t.Fatalf("GetByRecordId(42) error = %v, want an UpstreamError", err)
+	}
+
+	if !strings.Contains(upstreamErr.Error(), "rechercheExploitant.exploitantDetails.action_dossier.id_42.html") {
+		t.Errorf("error %q does not name the missing fixture", upstreamErr)
+	}
+}
+
+// blockingFetcher waits for the request to be cancelled.
+type blockingFetcher struct{}
+
+func (blockingFetcher) Do(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+
+	return nil, req.Context().Err()
+}
+
+func TestFetchReportsContextErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		client *Client
+	}{
+		{"fetch", newFixtureClient(WithFetcher(blockingFetcher{}))},
+		{"retry backoff", newFixtureClient(WithFetcher(statusFetcher(http.StatusBadGateway)), WithRetry(3, time.Minute, time.Minute))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			if _, err := test.client.GetByRecordId(timeoutCtx, 1234); err != context.DeadlineExceeded {
+				t.Errorf("GetByRecordId with an expired deadline error = %v, want %v", err, context.DeadlineExceeded)
+			}
+
+			canceledCtx, cancel := context.WithCancel(ctx)
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			if _, err := test.client.GetByRecordId(canceledCtx, 1234); err != context.Canceled {
+				t.Errorf("GetByRecordId with a cancelled context error = %v, want %v", err, context.Canceled)
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+package revtc
+
+// Offline reference tables used by NormalizeAddress.
+
+type department struct {
+	code   string
+	name   string
+	region string
+}
+
+// departments lists the French departments and overseas collectivities.
+// Collectivities that belong to no region have an empty region.
+var departments = []department{
+	{"01", "Ain", "Auvergne-Rhône-Alpes"},
+	{"02", "Aisne", "Hauts-de-France"},
+	{"03", "Allier", "Auvergne-Rhône-Alpes"},
+	{"04", "Alpes-de-Haute-Provence", "Provence-Alpes-Côte d'Azur"},
+	{"05", "Hautes-Alpes", "Provence-Alpes-Côte d'Azur"},
+	{"06", "Alpes-Maritimes", "Provence-Alpes-Côte d'Azur"},
+	{"07", "Ardèche", "Auvergne-Rhône-Alpes"},
+	{"08", "Ardennes", "Grand Est"},
+	{"09", "Ariège", "Occitanie"},
+	{"10", "Aube", "Grand Est"},
+	{"11", "Aude", "Occitanie"},
+	{"12", "Aveyron", "Occitanie"},
+	{"13", "Bouches-du-Rhône", "Provence-Alpes-Côte d'Azur"},
+	{"14", "Calvados", "Normandie"},
+	{"15", "Cantal", "Auvergne-Rhône-Alpes"},
+	{"16", "Charente", "Nouvelle-Aquitaine"},
+	{"17", "Charente-Maritime", "Nouvelle-Aquitaine"},
+	{"18", "Cher", "Centre-Val de Loire"},
+	{"19", "Corrèze", "Nouvelle-Aquitaine"},
+	{"2A", "Corse-du-Sud", "Corse"},
+	{"2B", "Haute-Corse", "Corse"},
+	{"21", "Côte-d'Or", "Bourgogne-Franche-Comté"},
+	{"22", "Côtes-d'Armor", "Bretagne"},
+	{"23", "Creuse", "Nouvelle-Aquitaine"},
+	{"24", "Dordogne", "Nouvelle-Aquitaine"},
+	{"25", "Doubs", "Bourgogne-Franche-Comté"},
+	{"26", "Drôme", "Auvergne-Rhône-Alpes"},
+	{"27", "Eure", "Normandie"},
+	{"28", "Eure-et-Loir", "Centre-Val de Loire"},
+	{"29", "Finistère", "Bretagne"},
+	{"30", "Gard", "Occitanie"},
+	{"31", "Haute-Garonne", "Occitanie"},
+	{"32", "Gers", "Occitanie"},
+	{"33", "Gironde", "Nouvelle-Aquitaine"},
+	{"34", "Hérault", "Occitanie"},
+	{"35", "Ille-et-Vilaine", "Bretagne"},
+	{"36", "Indre", "Centre-Val de Loire"},
+	{"37", "Indre-et-Loire", "Centre-Val de Loire"},
+	{"38", "Isère", "Auvergne-Rhône-Alpes"},
+	{"39", "Jura", "Bourgogne-Franche-Comté"},
+	{"40", "Landes", "Nouvelle-Aquitaine"},
+	{"41", "Loir-et-Cher", "Centre-Val de Loire"},
+	{"42", "Loire", "Auvergne-Rhône-Alpes"},
+	{"43", "Haute-Loire", "Auvergne-Rhône-Alpes"},
+	{"44", "Loire-Atlantique", "Pays de la Loire"},
+	{"45", "Loiret", "Centre-Val de Loire"},
+	{"46", "Lot", "Occitanie"},
+	{"47", "Lot-et-Garonne", "Nouvelle-Aquitaine"},
+	{"48", "Lozère", "Occitanie"},
+	{"49", "Maine-et-Loire", "Pays de la Loire"},
+	{"50", "Manche", "Normandie"},
+	{"51", "Marne", "Grand Est"},
+	{"52", "Haute-Marne", "Grand Est"},
+	{"53", "Mayenne", "Pays de la Loire"},
+	{"54", "Meurthe-et-Moselle", "Grand Est"},
+	{"55", "Meuse", "Grand Est"},
+	{"56", "Morbihan", "Bretagne"},
+	{"57", "Moselle", "Grand Est"},
+	{"58", "Nièvre", "Bourgogne-Franche-Comté"},
+	{"59", "Nord", "Hauts-de-France"},
+	{"60", "Oise", "Hauts-de-France"},
+	{"61", "Orne", "Normandie"},
+	{"62", "Pas-de-Calais", "Hauts-de-France"},
+	{"63", "Puy-de-Dôme", "Auvergne-Rhône-Alpes"},
+	{"64", "Pyrénées-Atlantiques", "Nouvelle-Aquitaine"},
+	{"65", "Hautes-Pyrénées", "Occitanie"},
+	{"66", "Pyrénées-Orientales", "Occitanie"},
+	{"67", "Bas-Rhin", "Grand Est"},
+	{"68", "Haut-Rhin", "Grand Est"},
+	{"69", "Rhône", "Auvergne-Rhône-Alpes"},
+	{"70", "Haute-Saône", "Bourgogne-Franche-Comté"},
+	{"71", "Saône-et-Loire", "Bourgogne-Franche-Comté"},
+	{"72", "Sarthe", "Pays de la Loire"},
+	{"73", "Savoie", "Auvergne-Rhône-Alpes"},
+	{"74", "Haute-Savoie", "Auvergne-Rhône-Alpes"},
+	{"75", "Paris", "Île-de-France"},
+	{"76", "Seine-Maritime", "Normandie"},
+	{"77", "Seine-et-Marne", "Île-de-France"},
+	{"78", "Yvelines", "Île-de-France"},
+	{"79", "Deux-Sèvres", "Nouvelle-Aquitaine"},
+	{"80", "Somme", "Hauts-de-France"},
+	{"81", "Tarn", "Occitanie"},
+	{"82", "Tarn-et-Garonne", "Occitanie"},
+	{"83", "Var", "Provence-Alpes-Côte d'Azur"},
+	{"84", "Vaucluse", "Provence-Alpes-Côte d'Azur"},
+	{"85", "Vendée", "Pays de la Loire"},
+	{"86", "Vienne", "Nouvelle-Aquitaine"},
+	{"87", "Haute-Vienne", "Nouvelle-Aquitaine"},
+	{"88", "Vosges", "Grand Est"},
+	{"89", "Yonne", "Bourgogne-Franche-Comté"},
+	{"90", "Territoire de Belfort", "Bourgogne-Franche-Comté"},
+	{"91", "Essonne", "Île-de-France"},
+	{"92", "Hauts-de-Seine", "Île-de-France"},
+	{"93", "Seine-Saint-Denis", "Île-de-France"},
+	{"94", "Val-de-Marne", "Île-de-France"},
+	{"95", "Val-d'Oise", "Île-de-France"},
+	{"971", "Guadeloupe", "Guadeloupe"},
+	{"972", "Martinique", "Martinique"},
+	{"973", "Guyane", "Guyane"},
+	{"974", "La Réunion", "La Réunion"},
+	{"975", "Saint-Pierre-et-Miquelon", ""},
+	{"976", "Mayotte", "Mayotte"},
+	{"977", "Saint-Barthélemy", ""},
+	{"978", "Saint-Martin", ""},
+	{"986", "Wallis-et-Futuna", ""},
+	{"987", "Polynésie française", ""},
+	{"988", "Nouvelle-Calédonie", ""},
+}
+
+// countryNames maps the French names of the ISO 3166-1 countries, as
+// published in the iso-codes translations, and their usual short forms to
+// their alpha-2 code.
+var countryNames = map[string]string{
+	"Afghanistan":                     "AF",
+	"Afrique du Sud":                  "ZA",
+	"Åland":                           "AX",
+	"Åland, Îles":                     "AX",
+	"Albanie":                         "AL",
+	"Algérie":                         "DZ",
+	"Allemagne":                       "DE",
+	"Andorre":                         "AD",
+	"Angleterre":                      "GB",
+	"Angola":                          "AO",
+	"Anguilla":                        "AI",
+	"Antarctique":                     "AQ",
+	"Antigua-et-Barbuda":              "AG",
+	"Arabie saoudite":                 "SA",
+	"Argentine":                       "AR",
+	"Arménie":                         "AM",
+	"Aruba":                           "AW",
+	"Australie":                       "AU",
+	"Autriche":                        "AT",
+	"Azerbaïdjan":                     "AZ",
+	"Bahamas":                         "BS",
+	"Bahreïn":                         "BH",
+	"Bangladesh":                      "BD",
+	"Barbade":                         "BB",
+	"Bélarus":                         "BY",
+	"Belgique":                        "BE",
+	"Belize":                          "BZ",
+	"Bénin":                           "BJ",
+	"Bermudes":                        "BM",
+	"Bhoutan":                         "BT",
+	"Biélorussie":                     "BY",
+	"Birmanie":                        "MM",
+	"Bolivie":                         "BO",
+	"Bolivie, état plurinational de":  "BO",
+	"Bonaire":                         "BQ",
+	"Bonaire, Saint-Eustache et Saba": "BQ",
+	"Bosnie-Herzégovine":              "BA",
+	"Botswana":                        "BW",
+	"Brésil":                          "BR",
+	"Brunéi Darussalam":               "BN",
+	"Bulgarie":                        "BG",
+	"Burkina Faso":                    "BF",
+	"Burundi":                         "BI",
+	"Cabo Verde":                      "CV",
+	"Cambodge":                        "KH",
+	"Cameroun":                        "CM",
+	"Canada":                          "CA",
+	"Cap-Vert":                        "CV",
+	"Chili":                           "CL",
+	"Chine":                           "CN",
+	"Christmas":                       "CX",
+	"Christmas, Île":                  "CX",
+	"Chypre":                          "CY",
+	"Cocos (Keeling)":                 "CC",
+	"Cocos (Keeling), Îles":           "CC",
+	"Colombie":                        "CO",
+	"Comores":                         "KM",
+	"Congo":                           "CG",
+	"Corée du Nord":                   "KP",
+	"Corée du Sud":                    "KR",
+	"Corée, République de":            "KR",
+	"Corée, République populaire démocratique de": "KP",
+	"Costa Rica":            "CR",
+	"Côte d'Ivoire":         "CI",
+	"Croatie":               "HR",
+	"Cuba":                  "CU",
+	"Curaçao":               "CW",
+	"Danemark":              "DK",
+	"Djibouti":              "DJ",
+	"Dominique":             "DM",
+	"Écosse":                "GB",
+	"Égypte":                "EG",
+	"Émirats arabes unis":   "AE",
+	"Équateur":              "EC",
+	"Érythrée":              "ER",
+	"Espagne":               "ES",
+	"Estonie":               "EE",
+	"Eswatini":              "SZ",
+	"États-Unis":            "US",
+	"États-Unis d'Amérique": "US",
+	"Éthiopie":              "ET",
+	"Fidji":                 "FJ",
+	"Finlande":              "FI",
+	"France":                "FR",
+	"Gabon":                 "GA",
+	"Gambie":                "GM",
+	"Géorgie":               "GE",
+	"Géorgie du Sud et les îles Sandwich du Sud": "GS",
+	"Ghana":                   "GH",
+	"Gibraltar":               "GI",
+	"Grande-Bretagne":         "GB",
+	"Grèce":                   "GR",
+	"Grenade":                 "GD",
+	"Groënland":               "GL",
+	"Guadeloupe":              "GP",
+	"Guam":                    "GU",
+	"Guatemala":               "GT",
+	"Guernesey":               "GG",
+	"Guinée":                  "GN",
+	"Guinée-Bissau":           "GW",
+	"Guinée Équatoriale":      "GQ",
+	"Guyana":                  "GY",
+	"Guyane":                  "GF",
+	"Guyane française":        "GF",
+	"Haïti":                   "HT",
+	"Hollande":                "NL",
+	"Honduras":                "HN",
+	"Hong Kong":               "HK",
+	"Hongrie":                 "HU",
+	"île Bouvet":              "BV",
+	"Île de Man":              "IM",
+	"Île Maurice":             "MU",
+	"île Norfolk":             "NF",
+	"Îles Åland":              "AX",
+	"îles Caïmans":            "KY",
+	"îles Cook":               "CK",
+	"îles Féroé":              "FO",
+	"îles Heard-et-MacDonald": "HM",
+	"Îles Malouines":          "FK",
+	"Îles Mariannes du Nord":  "MP",
+	"Îles Marshall":           "MH",
+	"Îles mineures éloignées des États-Unis": "UM",
+	"Îles Pitcairn":                 "PN",
+	"îles Turques-et-Caïques":       "TC",
+	"Îles Vierges britanniques":     "VG",
+	"Îles Vierges, États-Unis":      "VI",
+	"Inde":                          "IN",
+	"Indonésie":                     "ID",
+	"Irak":                          "IQ",
+	"Iran":                          "IR",
+	"Iran, République islamique d'": "IR",
+	"Irlande":                       "IE",
+	"Islande":                       "IS",
+	"Israël":                        "IL",
+	"Italie":                        "IT",
+	"Jamaïque":                      "JM",
+	"Japon":                         "JP",
+	"Jersey":                        "JE",
+	"Jordanie":                      "JO",
+	"Kazakhstan":                    "KZ",
+	"Kenya":                         "KE",
+	"Kirghizistan":                  "KG",
+	"Kiribati":                      "KI",
+	"Koweït":                        "KW",
+	"La Réunion":                    "RE",
+	"Lao":                           "LA",
+	"Lao, République démocratique populaire": "LA",
+	"Laos":                               "LA",
+	"Lesotho":                            "LS",
+	"Lettonie":                           "LV",
+	"Liban":                              "LB",
+	"Libéria":                            "LR",
+	"Libye":                              "LY",
+	"Liechtenstein":                      "LI",
+	"Lituanie":                           "LT",
+	"Luxembourg":                         "LU",
+	"Macao":                              "MO",
+	"Macau":                              "MO",
+	"Macédoine du Nord":                  "MK",
+	"Madagascar":                         "MG",
+	"Malaisie":                           "MY",
+	"Malawi":                             "MW",
+	"Maldives":                           "MV",
+	"Mali":                               "ML",
+	"Malouines":                          "FK",
+	"Malouines, Îles (Falkland)":         "FK",
+	"Malte":                              "MT",
+	"Maroc":                              "MA",
+	"Martinique":                         "MQ",
+	"Maurice":                            "MU",
+	"Mauritanie":                         "MR",
+	"Mayotte":                            "YT",
+	"Mexique":                            "MX",
+	"Micronésie":                         "FM",
+	"Micronésie, États fédérés de":       "FM",
+	"Moldavie":                           "MD",
+	"Moldova":                            "MD",
+	"Moldova, République de":             "MD",
+	"Monaco":                             "MC",
+	"Mongolie":                           "MN",
+	"Monténégro":                         "ME",
+	"Montserrat":                         "MS",
+	"Mozambique":                         "MZ",
+	"Myanmar":                            "MM",
+	"Namibie":                            "NA",
+	"Nauru":                              "NR",
+	"Népal":                              "NP",
+	"Nicaragua":                          "NI",
+	"Niger":                              "NE",
+	"Nigeria":                            "NG",
+	"Nioue":                              "NU",
+	"Norvège":                            "NO",
+	"Nouvelle-Calédonie":                 "NC",
+	"Nouvelle-Zélande":                   "NZ",
+	"Oman":                               "OM",
+	"Ouganda":                            "UG",
+	"Ouzbékistan":                        "UZ",
+	"Pakistan":                           "PK",
+	"Palaos":                             "PW",
+	"Palestine":                          "PS",
+	"Palestine, État de":                 "PS",
+	"Panama":                             "PA",
+	"Papouasie-Nouvelle-Guinée":          "PG",
+	"Paraguay":                           "PY",
+	"Pays-Bas":                           "NL",
+	"Pays de Galles":                     "GB",
+	"Pérou":                              "PE",
+	"Philippines":                        "PH",
+	"Pologne":                            "PL",
+	"Polynésie française":                "PF",
+	"Porto Rico":                         "PR",
+	"Portugal":                           "PT",
+	"Qatar":                              "QA",
+	"RDC":                                "CD",
+	"République centrafricaine":          "CF",
+	"République démocratique du Congo":   "CD",
+	"République dominicaine":             "DO",
+	"République du Congo":                "CG",
+	"République tchèque":                 "CZ",
+	"Réunion":                            "RE",
+	"Réunion, Île de la":                 "RE",
+	"Roumanie":                           "RO",
+	"Royaume-Uni":                        "GB",
+	"Russie":                             "RU",
+	"Russie, Fédération de":              "RU",
+	"Rwanda":                             "RW",
+	"Sahara occidental":                  "EH",
+	"Saint-Barthélemy":                   "BL",
+	"Saint-Christophe-et-Niévès":         "KN",
+	"Saint-Marin":                        "SM",
+	"Saint-Martin":                       "MF",
+	"Saint-Martin (partie française)":    "MF",
+	"Saint-Martin (partie néerlandaise)": "SX",
+	"Saint-Pierre-et-Miquelon":           "PM",
+	"Saint-Siège (état de la cité du Vatican)":     "VA",
+	"Saint-Vincent-et-les-Grenadines":              "VC",
+	"Sainte-Hélène":                                "SH",
+	"Sainte-Hélène, Ascension et Tristan da Cunha": "SH",
+	"Sainte-Lucie":                                 "LC",
+	"Salomon":                                      "SB",
+	"Salomon, Îles":                                "SB",
+	"Salvador":                                     "SV",
+	"Samoa":                                        "WS",
+	"Samoa américaines":                            "AS",
+	"Sao Tomé-et-Principe":                         "ST",
+	"Sénégal":                                      "SN",
+	"Serbie":                                       "RS",
+	"Seychelles":                                   "SC",
+	"Sierra Leone":                                 "SL",
+	"Singapour":                                    "SG",
+	"Sint Maarten":                                 "SX",
+	"Slovaquie":                                    "SK",
+	"Slovénie":                                     "SI",
+	"Somalie":                                      "SO",
+	"Soudan":                                       "SD",
+	"Soudan du Sud":                                "SS",
+	"Sri Lanka":                                    "LK",
+	"Suède":                                        "SE",
+	"Suisse":                                       "CH",
+	"Surinam":                                      "SR",
+	"Suriname":                                     "SR",
+	"Svalbard et île Jan Mayen":                    "SJ",
+	"Swaziland":                                    "SZ",
+	"Syrie":                                        "SY",
+	"Syrienne, République arabe":                   "SY",
+	"Tadjikistan":                                  "TJ",
+	"Taïwan":                                       "TW",
+	"Taïwan, province de Chine":                    "TW",
+	"Tanzanie":                                     "TZ",
+	"Tanzanie, République unie de":                 "TZ",
+	"Tchad":                                        "TD",
+	"Tchéquie":                                     "CZ",
+	"Terres australes françaises":                  "TF",
+	"Territoire britannique de l'océan Indien": "IO",
+	"Thaïlande":         "TH",
+	"Timor-Leste":       "TL",
+	"Timor oriental":    "TL",
+	"Togo":              "TG",
+	"Tokelau":           "TK",
+	"Tonga":             "TO",
+	"Trinité-et-Tobago": "TT",
+	"Tunisie":           "TN",
+	"Türkiye":           "TR",
+	"Turkménistan":      "TM",
+	"Turquie":           "TR",
+	"Tuvalu":            "TV",
+	"Ukraine":           "UA",
+	"Uruguay":           "UY",
+	"USA":               "US",
+	"Vanuatu":           "VU",
+	"Vatican":           "VA",
+	"Vénézuela":         "VE",
+	"Vénézuela, république bolivarienne du": "VE",
+	"Viêt Nam":         "VN",
+	"Vietnam":          "VN",
+	"Wallis et Futuna": "WF",
+	"Yémen":            "YE",
+	"Zambie":           "ZM",
+	"Zimbabwe":         "ZW",
+}
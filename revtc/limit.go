@@ -0,0 +1,96 @@
+package revtc
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"time"
+)
+
+const (
+	// DefaultRateLimit is the number of requests per second sent to the
+	// registry.
+	DefaultRateLimit = 2
+	// DefaultRateBurst is the number of requests that may be sent at once
+	// after a quiet period.
+	DefaultRateBurst = 5
+	// DefaultMaxConcurrency caps the requests in flight toward the registry.
+	DefaultMaxConcurrency = 4
+	// DefaultMaxWait is how long a request may wait for its turn before
+	// failing with ErrRateLimited.
+	DefaultMaxWait = 30 * time.Second
+)
+
+// WithRateLimit sets the token bucket shared by every request sent to the
+// registry. A zero perSecond disables rate limiting.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.rateLimit = perSecond
+		c.rateBurst = burst
+	}
+}
+
+// WithMaxConcurrency caps the requests in flight toward the registry. Zero
+// removes the cap.
+func WithMaxConcurrency(maxConcurrency int) Option {
+	return func(c *Client) {
+		c.maxConcurrency = maxConcurrency
+	}
+}
+
+// WithMaxWait bounds how long a request waits for the rate limiter and the
+// concurrency cap before failing with ErrRateLimited. Zero waits for as long
+// as the lookup context allows.
+func WithMaxWait(maxWait time.Duration) Option {
+	return func(c *Client) {
+		c.maxWait = maxWait
+	}
+}
+
+func (c *Client) initLimits() {
+	if c.rateLimit > 0 {
+		c.limiter = rate.NewLimiter(rate.Limit(c.rateLimit), c.rateBurst)
+	}
+
+	if c.maxConcurrency > 0 {
+		c.slots = make(chan struct{}, c.maxConcurrency)
+	}
+}
+
+// acquire waits for a rate limiter token and a concurrency slot. The
+// returned function releases the slot.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	waitCtx := ctx
+
+	if c.maxWait > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, c.maxWait)
+		defer cancel()
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(waitCtx); err != nil {
+			return nil, waitError(ctx)
+		}
+	}
+
+	if c.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-waitCtx.Done():
+		return nil, waitError(ctx)
+	}
+}
+
+// waitError tells a lookup cancelled by its caller apart from one that
+// waited too long for its turn.
+func waitError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ErrRateLimited
+}
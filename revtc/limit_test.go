@@ -0,0 +1,118 @@
+package revtc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowFetcher answers after delay, recording how many requests it served
+// at once at most.
+type slowFetcher struct {
+	delay time.Duration
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (f *slowFetcher) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	f.inFlight++
+
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+
+	return FixtureFetcher{Dir: "testdata"}.Do(req)
+}
+
+func TestRateLimitedWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxWait time.Duration
+		timeout time.Duration
+	}{
+		// the next token comes after the lookup deadline
+		{"context deadline", 0, 100 * time.Millisecond},
+		{"max wait", 100 * time.Millisecond, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newFixtureClient(WithRateLimit(0.1, 1), WithMaxWait(test.maxWait))
+
+			if _, err := client.GetByRecordId(ctx, 1234); err != nil {
+				t.Fatal(err)
+			}
+
+			lookupCtx := ctx
+
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				lookupCtx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			_, err := client.GetByRecordId(lookupCtx, 1234)
+
+			if err != ErrRateLimited {
+				t.Errorf("second lookup error = %v, want %v", err, ErrRateLimited)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("second lookup failed after %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	fetcher := &slowFetcher{delay: 20 * time.Millisecond}
+	client := newFixtureClient(WithFetcher(fetcher), WithMaxConcurrency(2))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := client.GetByRecordId(ctx, 1234); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if fetcher.maxInFlight != 2 {
+		t.Errorf("%d requests in flight at most, want 2", fetcher.maxInFlight)
+	}
+}
+
+func TestMaxConcurrencyWait(t *testing.T) {
+	fetcher := &slowFetcher{delay: time.Second}
+	client := newFixtureClient(WithFetcher(fetcher), WithMaxConcurrency(1), WithMaxWait(50*time.Millisecond))
+
+	go client.GetByRecordId(ctx, 1234)
+
+	// let the first lookup take the only slot
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := client.GetByRecordId(ctx, 1234); err != ErrRateLimited {
+		t.Errorf("lookup waiting for a slot error = %v, want %v", err, ErrRateLimited)
+	}
+}
@@ -0,0 +1,238 @@
+package revtc
+
+import (
+	"context"
+	"github.com/andybalholm/cascadia"
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"golang.org/x/net/html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	recordIdPattern   = regexp.MustCompile(`dossier\.id=(\d+)`)
+	pageParamPattern  = regexp.MustCompile(`^d-\d+-p$`)
+	totalCountPattern = regexp.MustCompile(`(\d[\d\s.]*)\s+(?:éléments?|résultats?|exploitants?)\s+trouvés?`)
+)
+
+// SearchResult is the outcome of a registry search.
+type SearchResult struct {
+	Entries []pb.VTCEntry `json:"entries"`
+	// Page is the last result list page that was resolved, starting at 1.
+	Page int `json:"page"`
+	// TotalCount is the number of operators matching the search, as
+	// reported by the registry.
+	TotalCount int `json:"total_count"`
+	// HasMore reports whether the result list has pages after Page.
+	HasMore bool `json:"has_more"`
+}
+
+// resultList is what a single page of the registry result list holds.
+type resultList struct {
+	recordIds  []int
+	totalCount int
+	// pageLinks maps the page numbers linked from the pager to their URL.
+	pageLinks map[int]*url.URL
+	pageParam string
+}
+
+func (c *Client) resolveResultPage(ctx context.Context, doc *html.Node, page int) (SearchResult, error) {
+	// a search matching a single operator lands on its detail page
+	entry, err := c.inspectPage(doc)
+
+	if err == nil {
+		entry.FetchedAt = ptypes.TimestampNow()
+		c.setValidityStatus(&entry)
+
+		return SearchResult{
+			Entries:    []pb.VTCEntry{entry},
+			Page:       page,
+			TotalCount: 1,
+		}, nil
+	}
+
+	// anything but a result list or a page saying nothing was found
+	if err != errResultList && err != ErrNotFound {
+		return SearchResult{}, err
+	}
+
+	list, err := c.handleResultListDocument(doc)
+
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	result := SearchResult{
+		Entries:    make([]pb.VTCEntry, 0, len(list.recordIds)),
+		Page:       page,
+		TotalCount: list.totalCount,
+	}
+
+	for linkedPage := range list.pageLinks {
+		if linkedPage > page {
+			result.HasMore = true
+		}
+	}
+
+	for _, recordId := range list.recordIds {
+		entry, err := c.GetByRecordId(ctx, recordId)
+
+		if err != nil {
+			return SearchResult{}, err
+		}
+
+		result.Entries = append(result.Entries, entry)
+	}
+
+	return result, nil
+}
+
+// fetchResultPage loads the given page of the result list shown in doc,
+// using one of its pager links as a template.
+func (c *Client) fetchResultPage(ctx context.Context, doc *html.Node, page int) (*html.Node, error) {
+	list, err := c.handleResultListDocument(doc)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pageUrl *url.URL
+
+	for _, link := range list.pageLinks {
+		pageUrl = link
+		break
+	}
+
+	if pageUrl == nil {
+		return nil, ErrPageOutOfRange
+	}
+
+	query := pageUrl.Query()
+	query.Set(list.pageParam, strconv.Itoa(page))
+	pageUrl.RawQuery = query.Encode()
+
+	return c.get(ctx, pageUrl.String())
+}
+
+// handleResultListDocument reads a result list page: the record ids linked
+// from its rows, in page order and without duplicates, the total hit count
+// and the pager links.
+func (c *Client) handleResultListDocument(doc *html.Node) (resultList, error) {
+	list := resultList{
+		recordIds: []int{},
+		pageLinks: map[int]*url.URL{},
+	}
+
+	sel, errCss := cascadia.Compile(`a[href*="dossier.id="]`)
+
+	if errCss != nil {
+		return list, errCss
+	}
+
+	seen := map[int]bool{}
+
+	for _, node := range sel.MatchAll(doc) {
+		recordId, ok := getRecordIdFromLink(node)
+
+		if !ok || seen[recordId] {
+			continue
+		}
+
+		seen[recordId] = true
+		list.recordIds = append(list.recordIds, recordId)
+	}
+
+	pagerSel, errCss := cascadia.Compile(".pagelinks a[href]")
+
+	if errCss != nil {
+		return list, errCss
+	}
+
+	base, err := url.Parse(c.baseURL + "/")
+
+	if err != nil {
+		return list, err
+	}
+
+	for _, node := range pagerSel.MatchAll(doc) {
+		link, err := base.Parse(getAttribute(node, "href"))
+
+		if err != nil {
+			continue
+		}
+
+		for key, values := range link.Query() {
+			if !pageParamPattern.MatchString(key) || len(values) == 0 {
+				continue
+			}
+
+			page, err := strconv.Atoi(values[0])
+
+			if err != nil {
+				continue
+			}
+
+			list.pageParam = key
+			list.pageLinks[page] = link
+		}
+	}
+
+	list.totalCount = len(list.recordIds)
+
+	if match := totalCountPattern.FindStringSubmatch(getText(doc)); match != nil {
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+
+			return -1
+		}, match[1])
+
+		if totalCount, err := strconv.Atoi(digits); err == nil {
+			list.totalCount = totalCount
+		}
+	}
+
+	return list, nil
+}
+
+func getRecordIdFromLink(node *html.Node) (int, bool) {
+	match := recordIdPattern.FindStringSubmatch(getAttribute(node, "href"))
+
+	if match == nil {
+		return 0, false
+	}
+
+	recordId, err := strconv.Atoi(match[1])
+
+	return recordId, err == nil
+}
+
+func getAttribute(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
+
+// getText concatenates every text node below node.
+func getText(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+
+	var text strings.Builder
+
+	for subNode := node.FirstChild; subNode != nil; subNode = subNode.NextSibling {
+		text.WriteString(getText(subNode))
+		text.WriteString(" ")
+	}
+
+	return text.String()
+}
@@ -0,0 +1,219 @@
+package revtc
+
+import (
+	pb "github.com/united-drivers/go-revtc/proto"
+	"sort"
+	"strings"
+)
+
+var personTitleMapping = []string{
+	pb.PERSON_TITLE_PERSON_TITLE_MR:  "M.",
+	pb.PERSON_TITLE_PERSON_TITLE_MRS: "Mme",
+}
+
+// businessEntityTypeMapping holds the legal form labels of the INSEE
+// catégories juridiques, as the registry spells them.
+var businessEntityTypeMapping = []string{
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SA:                 "Société anonyme",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SARL:               "Société à responsabilité limitée",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SAS:                "Société par actions simplifiée",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SASU:               "Société par actions simplifiée unipersonnelle",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EURL:               "Entreprise unipersonnelle à responsabilité limitée",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EI:                 "Entreprise individuelle",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR: "Micro-entrepreneur",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EIRL:               "Entrepreneur individuel à responsabilité limitée",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SNC:                "Société en nom collectif",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCS:                "Société en commandite simple",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCA:                "Société en commandite par actions",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCOP:               "Société coopérative ouvrière de production",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCIC:               "Société coopérative d'intérêt collectif",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_COOPERATIVE:        "Société coopérative",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELARL:             "Société d'exercice libéral à responsabilité limitée",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELAS:              "Société d'exercice libéral par actions simplifiée",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELAFA:             "Société d'exercice libéral à forme anonyme",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCI:                "Société civile immobilière",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCP:                "Société civile professionnelle",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SEM:                "Société d'économie mixte",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GIE:                "Groupement d'intérêt économique",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_ASSOCIATION:        "Association déclarée",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_PUBLIC_ENTITY:      "Établissement public",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SOCIETE_CIVILE:     "Société civile",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCM:                "Société civile de moyens",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GAEC:               "Groupement agricole d'exploitation en commun",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EARL:               "Exploitation agricole à responsabilité limitée",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCEA:               "Société civile d'exploitation agricole",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SE:                 "Société européenne",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELCA:              "Société d'exercice libéral en commandite par actions",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SEP:                "Société en participation",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_INDIVISION:         "Indivision",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_FOREIGN_COMPANY:    "Société commerciale étrangère",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GIP:                "Groupement d'intérêt public",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_FOUNDATION:         "Fondation",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MUTUAL:             "Mutuelle",
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_PROFESSIONAL_BODY:  "Ordre professionnel",
+}
+
+// businessEntityTypeAliases lists abbreviations and other spellings of the
+// legal forms, compared after normalizeLegalForm.
+var businessEntityTypeAliases = map[pb.BUSINESS_ENTITY_TYPE][]string{
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SA:                 {"sa"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SARL:               {"sarl"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SAS:                {"sas"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SASU:               {"sasu", "sas a associe unique", "societe par actions simplifiee a associe unique"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EURL:               {"eurl", "sarl unipersonnelle", "sarl a associe unique", "societe a responsabilite limitee unipersonnelle", "societe a responsabilite limitee a associe unique"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EI:                 {"ei", "entrepreneur individuel", "commercant", "artisan", "artisan commercant"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR: {"microentrepreneur", "micro entreprise", "microentreprise", "auto entrepreneur", "autoentrepreneur"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EIRL:               {"eirl"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SNC:                {"snc"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCS:                {"scs"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCA:                {"sca"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCOP:               {"scop", "scop sa", "scop sarl", "scop sas", "societe cooperative et participative"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCIC:               {"scic"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_COOPERATIVE:        {"cooperative"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELARL:             {"selarl", "selurl"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELAS:              {"selas", "selasu"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELAFA:             {"selafa"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCI:                {"sci"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCP:                {"scp"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SEM:                {"sem", "saem", "societe anonyme d economie mixte"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GIE:                {"gie"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_ASSOCIATION:        {"association", "association loi 1901"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_PUBLIC_ENTITY:      {"etablissement public", "collectivite territoriale"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SOCIETE_CIVILE:     {"sc", "autre societe civile"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCM:                {"scm"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GAEC:               {"gaec"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EARL:               {"earl"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCEA:               {"scea"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SE:                 {"se", "societe europeenne"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELCA:              {"selca"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SEP:                {"sep"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_INDIVISION:         {"indivision entre personnes physiques"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_FOREIGN_COMPANY:    {"societe etrangere", "societe commerciale etrangere immatriculee au rcs"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GIP:                {"gip"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_FOUNDATION:         {"fondation reconnue d utilite publique"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MUTUAL:             {"societe d assurance mutuelle"},
+	pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_PROFESSIONAL_BODY:  {"syndicat professionnel", "chambre consulaire"},
+}
+
+// legalFormCandidate is a normalized spelling of a legal form.
+type legalFormCandidate struct {
+	value      string
+	entityType pb.BUSINESS_ENTITY_TYPE
+}
+
+// legalFormCandidates holds every spelling of every legal form, micro
+// entrepreneurs first as they are also individual entrepreneurs, then the
+// longest spellings first so that the most specific form wins.
+var legalFormCandidates = buildLegalFormCandidates()
+
+func buildLegalFormCandidates() []legalFormCandidate {
+	candidates := []legalFormCandidate{}
+
+	for entityType, label := range businessEntityTypeMapping {
+		if label == "" {
+			continue
+		}
+
+		candidates = append(candidates, legalFormCandidate{normalizeLegalForm(label), pb.BUSINESS_ENTITY_TYPE(entityType)})
+
+		for _, alias := range businessEntityTypeAliases[pb.BUSINESS_ENTITY_TYPE(entityType)] {
+			candidates = append(candidates, legalFormCandidate{normalizeLegalForm(alias), pb.BUSINESS_ENTITY_TYPE(entityType)})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		iMicro := candidates[i].entityType == pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR
+		jMicro := candidates[j].entityType == pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR
+
+		if iMicro != jMicro {
+			return iMicro
+		}
+
+		return len(candidates[i].value) > len(candidates[j].value)
+	})
+
+	return candidates
+}
+
+var accentReplacer = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "å", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i",
+	"ô", "o", "ö", "o",
+	"ù", "u", "û", "u", "ü", "u",
+	"ÿ", "y",
+	"œ", "oe", "æ", "ae",
+)
+
+// normalizeWords lowercases value, strips accents and turns any punctuation
+// into single spaces.
+func normalizeWords(value string) []string {
+	value = accentReplacer.Replace(strings.ToLower(value))
+
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+}
+
+// normalizeLegalForm is normalizeWords ignoring dots, so that "S.A.R.L."
+// reads "sarl".
+func normalizeLegalForm(value string) string {
+	words := normalizeWords(strings.Replace(value, ".", "", -1))
+
+	for i, word := range words {
+		if word == "ste" {
+			words[i] = "societe"
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+var legalEntityTypeMapping = []string{
+	pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_COMPANY:    "Personne morale",
+	pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_INDIVIDUAL: "Personne physique",
+}
+
+func getKeyForMappingValue(mapping []string, inputValue string, defaultValue int) int {
+	for key, value := range mapping {
+		if value == inputValue {
+			return key
+		}
+	}
+
+	return defaultValue
+}
+
+func castAPIPersonTitle(str string) pb.PERSON_TITLE {
+	return pb.PERSON_TITLE(getKeyForMappingValue(personTitleMapping, str, int(pb.PERSON_TITLE_PERSON_TITLE_OTHER)))
+}
+
+// castAPIBusinessEntityType matches a legal form regardless of case, accents
+// and abbreviations, first on the whole value then on the most specific
+// legal form it mentions.
+func castAPIBusinessEntityType(str string) pb.BUSINESS_ENTITY_TYPE {
+	value := normalizeLegalForm(str)
+
+	if value == "" {
+		return pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER
+	}
+
+	for _, candidate := range legalFormCandidates {
+		if candidate.value == value {
+			return candidate.entityType
+		}
+	}
+
+	for _, candidate := range legalFormCandidates {
+		if strings.Contains(" "+value+" ", " "+candidate.value+" ") {
+			return candidate.entityType
+		}
+	}
+
+	return pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER
+}
+
+func castAPILegalEntityType(str string) pb.LEGAL_ENTITY_TYPE {
+	return pb.LEGAL_ENTITY_TYPE(getKeyForMappingValue(legalEntityTypeMapping, str, int(pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_OTHER)))
+}
@@ -0,0 +1,60 @@
+package revtc
+
+import (
+	pb "github.com/united-drivers/go-revtc/proto"
+	"testing"
+)
+
+func TestCastAPIBusinessEntityType(t *testing.T) {
+	tests := []struct {
+		legalForm string
+		want      pb.BUSINESS_ENTITY_TYPE
+	}{
+		{"Société à responsabilité limitée", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SARL},
+		// case, accents and punctuation
+		{"SOCIETE A RESPONSABILITE LIMITEE", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SARL},
+		{"S.A.R.L.", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SARL},
+		{"s.a.s", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SAS},
+		{"Sté par actions simplifiée", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SAS},
+		{"  Société anonyme ", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SA},
+		// single partner forms
+		{"SAS à associé unique", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SASU},
+		{"SASU", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SASU},
+		{"SARL unipersonnelle", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EURL},
+		// individual entrepreneurs
+		{"Auto-entrepreneur", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR},
+		{"Entrepreneur individuel (micro-entreprise)", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR},
+		{"Entreprise individuelle", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EI},
+		{"Commerçant", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EI},
+		{"E.I.R.L.", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EIRL},
+		// civil and agricultural companies
+		{"Société civile", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SOCIETE_CIVILE},
+		{"Société civile immobilière", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCI},
+		{"SCI familiale", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCI},
+		{"Société civile de moyens", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCM},
+		{"Société civile professionnelle", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCP},
+		{"G.A.E.C.", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GAEC},
+		{"EARL", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_EARL},
+		{"Société civile d'exploitation agricole", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCEA},
+		// other categories
+		{"Société européenne", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SE},
+		{"SCOP SARL", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SCOP},
+		{"Coopérative ouvrière", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_COOPERATIVE},
+		{"SELARL", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SELARL},
+		{"Association loi 1901", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_ASSOCIATION},
+		{"Association déclarée", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_ASSOCIATION},
+		{"Groupement d'intérêt économique", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GIE},
+		{"Groupement d'intérêt public", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_GIP},
+		{"Société commerciale étrangère immatriculée au RCS", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_FOREIGN_COMPANY},
+		{"Établissement public", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_PUBLIC_ENTITY},
+		// unknown forms
+		{"Taxi", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER},
+		{"", pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_OTHER},
+	}
+
+	for _, test := range tests {
+		if got := castAPIBusinessEntityType(test.legalForm); got != test.want {
+			t.Errorf("castAPIBusinessEntityType(%q) = %v, want %v", test.legalForm, got, test.want)
+		}
+	}
+}
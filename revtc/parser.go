@@ -0,0 +1,302 @@
+package revtc
+
+import (
+	"fmt"
+	"github.com/andybalholm/cascadia"
+	google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"golang.org/x/net/html"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	lCompanyName         = "Dénomination"
+	lCompanyNumber       = "Numéro SIREN"
+	lRegistrationNumber  = "Numéro d'inscription"
+	lContactFirstName    = "Prénom"
+	lContactLastName     = "Nom"
+	lCity                = "Ville"
+	lAcronym             = "Sigle"
+	lExpirationDate      = "Valide jusqu'au"
+	lLegalEntityType     = "Statut"
+	lCompanyType         = "Forme juridique"
+	lBrand               = "Marque/Nom commercial"
+	lPostalCode          = "Code Postal"
+	lDepartment          = "Département"
+	lCountry             = "Pays"
+	lIndividualTitle     = "Civilité"
+	lIndividualFirstName = "Prénom principal"
+	lIndividualLastName  = "Nom d'usage"
+
+	// The labels below have not been checked against a live detail page
+	// yet, only against the synthetic pages of testdata. Should the
+	// registry word them differently, these fields stay empty and its
+	// labels are kept in VTCEntry.RawFields.
+	lRegistrationDate        = "Date d'inscription"
+	lAddressLine             = "Adresse"
+	lAddressComplement       = "Complément d'adresse"
+	lPhone                   = "Téléphone"
+	lEmail                   = "Courriel"
+	lVehicleCount            = "Nombre de véhicules"
+	lInsuranceCompany        = "Compagnie d'assurance"
+	lInsurancePolicyNumber   = "Numéro de contrat d'assurance"
+	lInsuranceExpirationDate = "Date de fin de validité de l'assurance"
+)
+
+// mappedLabels lists the labels mapDictToObject reads; any other label of a
+// detail page ends up in VTCEntry.RawFields.
+var mappedLabels = map[string]bool{
+	lCompanyName:             true,
+	lCompanyNumber:           true,
+	lRegistrationNumber:      true,
+	lContactFirstName:        true,
+	lContactLastName:         true,
+	lCity:                    true,
+	lAcronym:                 true,
+	lExpirationDate:          true,
+	lLegalEntityType:         true,
+	lCompanyType:             true,
+	lBrand:                   true,
+	lPostalCode:              true,
+	lDepartment:              true,
+	lCountry:                 true,
+	lIndividualTitle:         true,
+	lIndividualFirstName:     true,
+	lIndividualLastName:      true,
+	lRegistrationDate:        true,
+	lAddressLine:             true,
+	lAddressComplement:       true,
+	lPhone:                   true,
+	lEmail:                   true,
+	lVehicleCount:            true,
+	lInsuranceCompany:        true,
+	lInsurancePolicyNumber:   true,
+	lInsuranceExpirationDate: true,
+}
+
+func mapDictToObject(mapped map[string]string) pb.VTCEntry {
+	var result = pb.VTCEntry{
+		CompanyNumber:      mapped[lCompanyNumber],
+		RegistrationNumber: mapped[lRegistrationNumber],
+		LegalEntityType:    castAPILegalEntityType(mapped[lLegalEntityType]),
+	}
+
+	result.Address = &pb.Address{
+		City:       mapped[lCity],
+		Country:    mapped[lCountry],
+		PostalCode: mapped[lPostalCode],
+		Department: mapped[lDepartment],
+	}
+
+	for _, label := range []string{lAddressLine, lAddressComplement} {
+		if line := mapped[label]; line != "" {
+			result.Address.Lines = append(result.Address.Lines, line)
+		}
+	}
+
+	NormalizeAddress(result.Address)
+
+	result.Phone = mapped[lPhone]
+	result.Email = mapped[lEmail]
+	result.RegistrationDate = parseRegistryTimestamp(mapped[lRegistrationDate])
+
+	if vehicleCount, err := strconv.Atoi(mapped[lVehicleCount]); err == nil {
+		result.Vehicles = &pb.Vehicles{Count: int32(vehicleCount)}
+	}
+
+	if mapped[lInsuranceCompany] != "" || mapped[lInsurancePolicyNumber] != "" {
+		result.Insurance = &pb.Insurance{
+			Company:        mapped[lInsuranceCompany],
+			PolicyNumber:   mapped[lInsurancePolicyNumber],
+			ExpirationDate: parseRegistryTimestamp(mapped[lInsuranceExpirationDate]),
+		}
+	}
+
+	for label, value := range mapped {
+		if mappedLabels[label] || value == "" {
+			continue
+		}
+
+		if result.RawFields == nil {
+			result.RawFields = map[string]string{}
+		}
+
+		result.RawFields[label] = value
+	}
+
+	if result.LegalEntityType == pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_COMPANY {
+		result.Company = &pb.Company{
+			Name:    mapped[lCompanyName],
+			Acronym: mapped[lAcronym],
+			Contact: &pb.PersonName{
+				FirstName: mapped[lContactFirstName],
+				LastName:  mapped[lContactLastName],
+			},
+			CompanyType:    castAPIBusinessEntityType(mapped[lCompanyType]),
+			CompanyTypeRaw: mapped[lCompanyType],
+			Brand:          mapped[lBrand],
+		}
+
+	} else if result.LegalEntityType == pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_INDIVIDUAL {
+		result.Individual = &pb.Individual{
+			Title: castAPIPersonTitle(mapped[lIndividualTitle]),
+			Name: &pb.PersonName{
+				FirstName: mapped[lIndividualFirstName],
+				LastName:  mapped[lIndividualLastName],
+			},
+			CompanyType:    castAPIBusinessEntityType(mapped[lCompanyType]),
+			CompanyTypeRaw: mapped[lCompanyType],
+		}
+	}
+
+	// an unparseable date is left out rather than zeroed, the raw value
+	// tells what the registry said
+	result.ExpirationDateRaw = mapped[lExpirationDate]
+
+	result.ExpirationDate = parseRegistryTimestamp(result.ExpirationDateRaw)
+
+	return result
+}
+
+// parseRegistryTimestamp converts a registry date, returning nil when it
+// cannot be parsed.
+func parseRegistryTimestamp(value string) *google_protobuf.Timestamp {
+	date, err := parseRegistryDate(value)
+
+	if err != nil {
+		return nil
+	}
+
+	return &google_protobuf.Timestamp{
+		Seconds: date.Unix(),
+		Nanos:   int32(date.Nanosecond()),
+	}
+}
+
+func parsePage(res *http.Response) (*html.Node, error) {
+	if res.StatusCode != 200 {
+		return nil, &UpstreamError{
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+		}
+	}
+
+	doc, err := html.Parse(res.Body)
+
+	if err != nil {
+		return nil, &UpstreamError{StatusCode: res.StatusCode, Err: err}
+	}
+
+	return doc, nil
+}
+
+// handleSingleResultPage reads an operator from its detail page. Pages
+// without detail labels are ErrNotFound when they say nothing was found,
+// errResultList when they list operators, and a LayoutError otherwise.
+func handleSingleResultPage(doc *html.Node) (pb.VTCEntry, error) {
+	entry, _, err := inspectSingleResultPage(doc)
+
+	return entry, err
+}
+
+// inspectSingleResultPage is handleSingleResultPage also reporting how the
+// page matched the expected layout.
+func inspectSingleResultPage(doc *html.Node) (pb.VTCEntry, pageReport, error) {
+	sel, errCss := cascadia.Compile(".cLabel")
+
+	if errCss != nil {
+		return pb.VTCEntry{}, pageReport{}, errCss
+	}
+
+	mapped := map[string]string{}
+
+	results := sel.MatchAll(doc)
+
+	for _, node := range results {
+		parent := node.Parent
+
+		// edge case, in one of the tables labels are wrapped in a <span> elt
+		if parent.Data == "span" {
+			parent = parent.Parent
+		}
+
+		value := getTextToken(parent)
+
+		mapped[getTextToken(node)] = value
+	}
+
+	report := inspectLabels(mapped)
+
+	if report.kind != pageDetail {
+		report.kind = classifyPage(doc)
+
+		if report.kind == pageUnrecognised {
+			return pb.VTCEntry{}, report, &LayoutError{Reason: "page has neither detail labels nor a result list"}
+		}
+
+		// labels of a result list page belong to the search form
+		report.unknownLabels = nil
+
+		if report.kind == pageResultList {
+			return pb.VTCEntry{}, report, errResultList
+		}
+
+		return pb.VTCEntry{}, report, ErrNotFound
+	}
+
+	if mapped[lCompanyNumber] == "" {
+		// other detail labels without a SIREN means the page changed
+		return pb.VTCEntry{}, report, &LayoutError{Reason: fmt.Sprintf("detail page has no %q label", lCompanyNumber)}
+	}
+
+	result := mapDictToObject(mapped)
+	result.RecordId = int64(getRecordIdFromDetailDocument(doc))
+
+	return result, report, nil
+}
+
+// getRecordIdFromDetailDocument finds the dossier.id a detail page refers to,
+// either through a link or a hidden form field. It returns 0 when none is found.
+func getRecordIdFromDetailDocument(doc *html.Node) int {
+	sel, errCss := cascadia.Compile(`input[name="dossier.id"], a[href*="dossier.id="]`)
+
+	if errCss != nil {
+		return 0
+	}
+
+	for _, node := range sel.MatchAll(doc) {
+		if node.Data == "input" {
+			if recordId, err := strconv.Atoi(getAttribute(node, "value")); err == nil {
+				return recordId
+			}
+
+			continue
+		}
+
+		if recordId, ok := getRecordIdFromLink(node); ok {
+			return recordId
+		}
+	}
+
+	return 0
+}
+
+func getTextToken(node *html.Node) string {
+	subNode := node.FirstChild
+
+	for subNode != nil {
+		if subNode.Type == html.TextNode {
+			value := strings.TrimSpace(subNode.Data)
+
+			if value != "" {
+				return value
+			}
+		}
+
+		subNode = subNode.NextSibling
+	}
+
+	return ""
+}
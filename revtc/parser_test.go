@@ -0,0 +1,171 @@
+package revtc
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/golang/protobuf/jsonpb"
+	"golang.org/x/net/html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// go test ./revtc -run TestGoldenPages -update rewrites the golden files from
+// the current parser output; review the diff before committing it.
+var update = flag.Bool("update", false, "rewrite the golden files of testdata/golden")
+
+// goldenResult is what a golden file records: how the page matched the
+// expected layout, then the entry parsed from it, or the error parsing it
+// failed with.
+type goldenResult struct {
+	Page          string          `json:"page"`
+	UnknownLabels []string        `json:"unknown_labels,omitempty"`
+	MissingLabels []string        `json:"missing_labels,omitempty"`
+	Entry         json.RawMessage `json:"entry,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+func parseGoldenPage(t *testing.T, path string) []byte {
+	t.Helper()
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	doc, err := html.Parse(file)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, report, err := inspectSingleResultPage(doc)
+
+	result := goldenResult{
+		Page:          report.kind.String(),
+		UnknownLabels: report.unknownLabels,
+		MissingLabels: report.missingLabels,
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		marshaler := jsonpb.Marshaler{OrigName: true}
+		rendered, err := marshaler.MarshalToString(&entry)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result.Entry = json.RawMessage(rendered)
+	}
+
+	// re-indent through encoding/json, which sorts object keys
+	var document interface{}
+
+	if err := json.Unmarshal(mustMarshal(t, result), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := json.MarshalIndent(document, "", "  ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return append(golden, '\n')
+}
+
+// decodeGolden reads a golden document for comparison. Timestamps are
+// normalised since protobuf releases render them with or without trailing
+// zero fractions, and key order and indentation are ignored.
+func decodeGolden(t *testing.T, golden []byte) interface{} {
+	t.Helper()
+
+	var document interface{}
+
+	if err := json.Unmarshal(golden, &document); err != nil {
+		t.Fatal(err)
+	}
+
+	return normalizeTimestamps(document)
+}
+
+func normalizeTimestamps(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			value[key] = normalizeTimestamps(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = normalizeTimestamps(item)
+		}
+	case string:
+		if date, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return date.UTC().Format(time.RFC3339Nano)
+		}
+	}
+
+	return value
+}
+
+func mustMarshal(t *testing.T, value interface{}) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// TestGoldenPages parses every page of testdata/golden and compares the
+// result to the .golden.json file next to it.
+func TestGoldenPages(t *testing.T) {
+	pages, err := filepath.Glob(filepath.Join("testdata", "golden", "*.html"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pages) == 0 {
+		t.Fatal("no golden pages found")
+	}
+
+	for _, page := range pages {
+		name := strings.TrimSuffix(filepath.Base(page), ".html")
+		goldenPath := strings.TrimSuffix(page, ".html") + ".golden.json"
+
+		t.Run(name, func(t *testing.T) {
+			got := parseGoldenPage(t, page)
+
+			if *update {
+				if err := ioutil.WriteFile(goldenPath, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			want, err := ioutil.ReadFile(goldenPath)
+
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+
+			if !reflect.DeepEqual(decodeGolden(t, got), decodeGolden(t, want)) {
+				t.Errorf("%s does not match %s (run with -update if the change is expected):\n%s", page, goldenPath, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package revtc
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is how many times a request is sent before giving up.
+	DefaultMaxAttempts = 3
+	// DefaultRetryDelay is the backoff before the first retry; it doubles on
+	// every attempt.
+	DefaultRetryDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay caps the backoff between two attempts.
+	DefaultRetryMaxDelay = 10 * time.Second
+)
+
+// WithRetry configures how transient registry failures (unreachable host,
+// 5xx and 429 responses) are retried: up to maxAttempts requests in total,
+// with an exponential backoff starting at delay and capped at maxDelay.
+// Retry-After headers longer than maxDelay stop the retries.
+func WithRetry(maxAttempts int, delay time.Duration, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.retryDelay = delay
+		c.retryMaxDelay = maxDelay
+	}
+}
+
+// backoff returns how long to wait after the given failed attempt, and
+// false when the registry asked for a longer pause than allowed.
+func (c *Client) backoff(attempt int, retryAfter time.Duration) (time.Duration, bool) {
+	if retryAfter > c.retryMaxDelay {
+		return 0, false
+	}
+
+	delay := BackoffDelay(c.retryDelay, c.retryMaxDelay, attempt)
+
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+
+	return delay, true
+}
+
+// BackoffDelay returns how long to wait after the given failed attempt: base
+// doubled on every attempt and capped at max, with a random jitter bringing
+// it between half and the full delay so that retries do not fire together.
+func BackoffDelay(base time.Duration, max time.Duration, attempt int) time.Duration {
+	delay := base << uint(attempt-1)
+
+	if delay > max || delay <= 0 {
+		delay = max
+	}
+
+	if half := int64(delay / 2); half > 0 {
+		delay = time.Duration(half + rand.Int63n(half+1))
+	}
+
+	return delay
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
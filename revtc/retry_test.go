@@ -0,0 +1,169 @@
+package revtc
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scriptedFetcher answers the nth request with statuses[n], repeating the
+// last one, and serves the pages of testdata for http.StatusOK.
+type scriptedFetcher struct {
+	statuses   []int
+	retryAfter string
+
+	mu       sync.Mutex
+	requests []time.Time
+}
+
+func (f *scriptedFetcher) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	n := len(f.requests)
+	f.requests = append(f.requests, time.Now())
+	f.mu.Unlock()
+
+	status := f.statuses[len(f.statuses)-1]
+
+	if n < len(f.statuses) {
+		status = f.statuses[n]
+	}
+
+	if status == http.StatusOK {
+		return FixtureFetcher{Dir: "testdata"}.Do(req)
+	}
+
+	header := http.Header{}
+
+	if f.retryAfter != "" {
+		header.Set("Retry-After", f.retryAfter)
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		requests int
+		// status of the UpstreamError, 0 when the lookup succeeds
+		status int
+	}{
+		{"success", []int{200}, 1, 0},
+		{"transient failures", []int{503, 502, 200}, 3, 0},
+		{"too many requests", []int{429, 200}, 2, 0},
+		{"attempts exhausted", []int{503}, 3, 503},
+		{"client error", []int{404}, 1, 404},
+		{"forbidden", []int{403, 200}, 1, 403},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fetcher := &scriptedFetcher{statuses: test.statuses}
+			client := newFixtureClient(WithFetcher(fetcher), WithRetry(3, time.Millisecond, 5*time.Millisecond), WithCircuitBreaker(0, 0))
+
+			_, err := client.GetByRecordId(ctx, 1234)
+
+			if len(fetcher.requests) != test.requests {
+				t.Errorf("%d requests, want %d", len(fetcher.requests), test.requests)
+			}
+
+			if test.status == 0 {
+				if err != nil {
+					t.Errorf("error = %v, want none", err)
+				}
+
+				return
+			}
+
+			upstreamErr, ok := err.(*UpstreamError)
+
+			if !ok || upstreamErr.StatusCode != test.status || upstreamErr.Attempts != test.requests {
+				t.Errorf("error = %#v, want an UpstreamError with status %d after %d attempts", err, test.status, test.requests)
+			}
+		})
+	}
+}
+
+func TestRetryLayoutError(t *testing.T) {
+	fetcher := &scriptedFetcher{statuses: []int{200}}
+	client := newFixtureClient(WithFetcher(fetcher), WithRetry(3, time.Millisecond, time.Millisecond))
+
+	_, err := client.GetByCompanyNumber(ctx, "542065479")
+
+	if _, ok := err.(*LayoutError); !ok {
+		t.Fatalf("error = %v, want a LayoutError", err)
+	}
+
+	if len(fetcher.requests) != 1 {
+		t.Errorf("%d requests for a page that does not parse, want 1", len(fetcher.requests))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	fetcher := &scriptedFetcher{statuses: []int{503, 200}, retryAfter: "1"}
+	client := newFixtureClient(WithFetcher(fetcher), WithRetry(2, time.Millisecond, 5*time.Second))
+
+	if _, err := client.GetByRecordId(ctx, 1234); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fetcher.requests) != 2 {
+		t.Fatalf("%d requests, want 2", len(fetcher.requests))
+	}
+
+	if delay := fetcher.requests[1].Sub(fetcher.requests[0]); delay < time.Second {
+		t.Errorf("retried after %v despite Retry-After: 1", delay)
+	}
+
+	// the registry asks for a longer pause than the client allows
+	fetcher = &scriptedFetcher{statuses: []int{503, 200}, retryAfter: "60"}
+	client = newFixtureClient(WithFetcher(fetcher), WithRetry(2, time.Millisecond, 5*time.Second))
+
+	if _, err := client.GetByRecordId(ctx, 1234); err == nil || len(fetcher.requests) != 1 {
+		t.Errorf("Retry-After above the maximum delay: %d requests, error %v", len(fetcher.requests), err)
+	}
+}
+
+func TestRetryCanceledDuringBackoff(t *testing.T) {
+	fetcher := &scriptedFetcher{statuses: []int{503}}
+	client := newFixtureClient(WithFetcher(fetcher), WithRetry(3, time.Minute, time.Minute))
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.GetByRecordId(cancelCtx, 1234)
+
+	if err != context.Canceled {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+
+	if len(fetcher.requests) != 1 || time.Since(start) > time.Second {
+		t.Errorf("%d requests in %v, want 1 and the backoff cut short", len(fetcher.requests), time.Since(start))
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	for attempt := 1; attempt <= 6; attempt++ {
+		max := 100 * time.Millisecond << uint(attempt-1)
+
+		if max > time.Second {
+			max = time.Second
+		}
+
+		for i := 0; i < 20; i++ {
+			if delay := BackoffDelay(100*time.Millisecond, time.Second, attempt); delay < max/2 || delay > max {
+				t.Errorf("BackoffDelay after attempt %d = %v, want between %v and %v", attempt, delay, max/2, max)
+			}
+		}
+	}
+}
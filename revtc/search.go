@@ -0,0 +1,235 @@
+package revtc
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"golang.org/x/net/html"
+	"net/url"
+)
+
+// APISearchParams identifies a criterion of the registry advanced search.
+type APISearchParams int
+
+const (
+	SearchRegistrationNumber APISearchParams = iota
+	SearchCompanyNumber
+	SearchPersonName
+	SearchCompanyName
+	SearchAcronym
+	SearchBrand
+	SearchCity
+	SearchPostalCode
+	SearchDepartment
+)
+
+// GetByRecordId fetches the detail page of a registry record (dossier.id).
+func (c *Client) GetByRecordId(ctx context.Context, recordId int) (pb.VTCEntry, error) {
+	return c.cached(ctx, recordCacheKey(recordId), func(ctx context.Context) (pb.VTCEntry, error) {
+		return c.getByRecordId(ctx, recordId)
+	})
+}
+
+func (c *Client) getByRecordId(ctx context.Context, recordId int) (pb.VTCEntry, error) {
+	var requestUrl = fmt.Sprintf(
+		"%s/rechercheExploitant.exploitantDetails.action?dossier.id=%d",
+		c.baseURL, recordId)
+
+	doc, err := c.get(ctx, requestUrl)
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	result, err := c.inspectPage(doc)
+
+	if err == errResultList {
+		return pb.VTCEntry{}, &LayoutError{Reason: "record page is a result list"}
+	}
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	result.RecordId = int64(recordId)
+
+	return result, nil
+}
+
+func (c *Client) searchDocument(ctx context.Context, params map[APISearchParams]string) (*html.Node, error) {
+	var requestUrl = fmt.Sprintf(
+		"%s/rechercheExploitant.avancee.action", c.baseURL)
+
+	return c.postForm(ctx, requestUrl, url.Values{
+		"rechercheCriteres.numeroInscription":              {params[SearchRegistrationNumber]},
+		"rechercheCriteres.nomRepresentantLegal":           {params[SearchPersonName]},
+		"rechercheCriteres.nomDenomination":                {params[SearchCompanyName]},
+		"rechercheCriteres.numeroSiren":                    {params[SearchCompanyNumber]},
+		"rechercheCriteres.sigle":                          {params[SearchAcronym]},
+		"rechercheCriteres.marque":                         {params[SearchBrand]},
+		"rechercheCriteres.autreFormeJuridique":            {""},
+		"rechercheCriteres.idFormeJuridique":               {""},
+		"rechercheCriteres.ville":                          {params[SearchCity]},
+		"rechercheCriteres.idPays":                         {""},
+		"rechercheCriteres.codePostal":                     {params[SearchPostalCode]},
+		"rechercheCriteres.idRegion":                       {""},
+		"rechercheCriteres.idDepartement":                  {params[SearchDepartment]},
+		"action:/public/rechercheExploitant.liste.avancee": {"Rechercher"},
+	})
+}
+
+// GetByAdvancedSearch posts the registry advanced search form and expects
+// it to resolve to a single operator, either on its detail page or as the
+// only row of a result list. It fails with ErrAmbiguous when several
+// operators match; use Search to get them all.
+func (c *Client) GetByAdvancedSearch(ctx context.Context, params map[APISearchParams]string) (pb.VTCEntry, error) {
+	params, err := NormalizeSearchParams(params)
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	return c.cached(ctx, searchCacheKey(params), func(ctx context.Context) (pb.VTCEntry, error) {
+		return c.getByAdvancedSearch(ctx, params)
+	})
+}
+
+// RefreshByAdvancedSearch is GetByAdvancedSearch always querying the
+// registry. The fresh entry replaces the cached one.
+func (c *Client) RefreshByAdvancedSearch(ctx context.Context, params map[APISearchParams]string) (pb.VTCEntry, error) {
+	params, err := NormalizeSearchParams(params)
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	entry, err := c.load(ctx, searchCacheKey(params), func(ctx context.Context) (pb.VTCEntry, error) {
+		return c.getByAdvancedSearch(ctx, params)
+	})
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	c.setValidityStatus(&entry)
+
+	return entry, nil
+}
+
+func (c *Client) getByAdvancedSearch(ctx context.Context, params map[APISearchParams]string) (pb.VTCEntry, error) {
+	doc, err := c.searchDocument(ctx, params)
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	entry, err := c.inspectPage(doc)
+
+	if err != errResultList {
+		return entry, err
+	}
+
+	list, err := c.handleResultListDocument(doc)
+
+	if err != nil {
+		return pb.VTCEntry{}, err
+	}
+
+	if len(list.recordIds) > 1 || list.totalCount > 1 {
+		return pb.VTCEntry{}, ErrAmbiguous
+	}
+
+	if len(list.recordIds) == 0 {
+		return pb.VTCEntry{}, &LayoutError{Reason: "result list has no link to a record"}
+	}
+
+	// not through the cache, RefreshByAdvancedSearch wants a fresh entry
+	return c.getByRecordId(ctx, list.recordIds[0])
+}
+
+// Search posts the registry advanced search form and returns every matching
+// operator, following the result list across all of its pages. Each row is
+// resolved through GetByRecordId, so a city-wide search sends hundreds of
+// requests; SearchPage bounds the work to one page.
+func (c *Client) Search(ctx context.Context, params map[APISearchParams]string) (SearchResult, error) {
+	params, err := NormalizeSearchParams(params)
+
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	doc, err := c.searchDocument(ctx, params)
+
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	var entries []pb.VTCEntry
+
+	for page := 1; ; page++ {
+		result, err := c.resolveResultPage(ctx, doc, page)
+
+		if err != nil {
+			return SearchResult{}, err
+		}
+
+		entries = append(entries, result.Entries...)
+
+		if !result.HasMore {
+			result.Entries = entries
+			return result, nil
+		}
+
+		doc, err = c.fetchResultPage(ctx, doc, page+1)
+
+		if err != nil {
+			return SearchResult{}, err
+		}
+	}
+}
+
+// SearchPage is like Search but only resolves the given page (starting at 1)
+// of the result list, leaving pagination to the caller.
+func (c *Client) SearchPage(ctx context.Context, params map[APISearchParams]string, page int) (SearchResult, error) {
+	if page < 1 {
+		return SearchResult{}, ErrPageOutOfRange
+	}
+
+	params, err := NormalizeSearchParams(params)
+
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	doc, err := c.searchDocument(ctx, params)
+
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	if page > 1 {
+		doc, err = c.fetchResultPage(ctx, doc, page)
+
+		if err != nil {
+			return SearchResult{}, err
+		}
+	}
+
+	return c.resolveResultPage(ctx, doc, page)
+}
+
+// GetByCompanyNumber looks an operator up by SIREN. A SIRET is accepted and
+// reduced to its SIREN; invalid numbers never reach the registry.
+func (c *Client) GetByCompanyNumber(ctx context.Context, companyNumber string) (pb.VTCEntry, error) {
+	return c.GetByAdvancedSearch(ctx, map[APISearchParams]string{
+		SearchCompanyNumber: companyNumber,
+	})
+}
+
+// GetByRegistrationNumber looks an operator up by its EVTC registration
+// number; malformed numbers never reach the registry.
+func (c *Client) GetByRegistrationNumber(ctx context.Context, registrationNumber string) (pb.VTCEntry, error) {
+	return c.GetByAdvancedSearch(ctx, map[APISearchParams]string{
+		SearchRegistrationNumber: registrationNumber,
+	})
+}
@@ -0,0 +1,276 @@
+package revtc
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes"
+	google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"testing"
+)
+
+var ctx = context.Background()
+
+// newFixtureClient returns a client replaying the pages of testdata, without
+// retries, rate limits or drift logs.
+func newFixtureClient(opts ...Option) *Client {
+	return NewClient(append([]Option{
+		WithFetcher(FixtureFetcher{Dir: "testdata"}),
+		WithRateLimit(0, 0),
+		WithMaxConcurrency(0),
+		WithRetry(1, 0, 0),
+		WithDriftLog(nil, ""),
+	}, opts...)...)
+}
+
+// registryDate renders timestamp the way the registry writes dates.
+func registryDate(t *testing.T, timestamp *google_protobuf.Timestamp) string {
+	t.Helper()
+
+	if timestamp == nil {
+		return ""
+	}
+
+	date, err := ptypes.Timestamp(timestamp)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return date.In(registryLocation).Format(registryDateLayout)
+}
+
+func TestGetByRecordIdCompany(t *testing.T) {
+	entry, err := newFixtureClient().GetByRecordId(ctx, 1234)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		field string
+		got   interface{}
+		want  interface{}
+	}{
+		{"record id", entry.RecordId, int64(1234)},
+		{"company number", entry.CompanyNumber, "732829320"},
+		{"registration number", entry.RegistrationNumber, "EVTC075150001"},
+		{"legal entity type", entry.LegalEntityType, pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_COMPANY},
+		{"registration date", registryDate(t, entry.RegistrationDate), "02/03/2015"},
+		{"expiration date", registryDate(t, entry.ExpirationDate), "01/03/2030"},
+		{"expiration date raw", entry.ExpirationDateRaw, "01/03/2030"},
+		{"validity status", entry.ValidityStatus, pb.VALIDITY_STATUS_VALIDITY_STATUS_VALID},
+		{"company name", entry.GetCompany().GetName(), "TRANSPORTS DUPONT"},
+		{"acronym", entry.GetCompany().GetAcronym(), "TDP"},
+		{"brand", entry.GetCompany().GetBrand(), "Dupont Chauffeurs"},
+		{"company type", entry.GetCompany().GetCompanyType(), pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_SAS},
+		{"company type raw", entry.GetCompany().GetCompanyTypeRaw(), "SAS"},
+		{"contact last name", entry.GetCompany().GetContact().GetLastName(), "DUPONT"},
+		{"contact first name", entry.GetCompany().GetContact().GetFirstName(), "Jean"},
+		{"address lines", len(entry.GetAddress().GetLines()), 2},
+		{"postal code", entry.GetAddress().GetPostalCode(), "75002"},
+		{"city", entry.GetAddress().GetCity(), "PARIS CEDEX 02"},
+		{"city normalized", entry.GetAddress().GetCityNormalized(), "PARIS"},
+		{"department code", entry.GetAddress().GetDepartmentCode(), "75"},
+		{"region", entry.GetAddress().GetRegion(), "Île-de-France"},
+		{"country code", entry.GetAddress().GetCountryCode(), "FR"},
+		{"phone", entry.Phone, "01 23 45 67 89"},
+		{"email", entry.Email, "contact@dupont-chauffeurs.fr"},
+		{"vehicles", entry.GetVehicles().GetCount(), int32(12)},
+		{"insurance company", entry.GetInsurance().GetCompany(), "MUTUELLE DU TRANSPORT"},
+		{"insurance policy", entry.GetInsurance().GetPolicyNumber(), "MT-2024-0042"},
+		{"insurance expiration", registryDate(t, entry.GetInsurance().GetExpirationDate()), "31/12/2026"},
+		{"raw fields", entry.RawFields["Attestation de capacité"], "ATT-75-0099"},
+		{"individual", entry.Individual == nil, true},
+	}
+
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", check.field, check.got, check.want)
+		}
+	}
+}
+
+func TestGetByRecordIdIndividual(t *testing.T) {
+	entry, err := newFixtureClient().GetByRecordId(ctx, 5678)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		field string
+		got   interface{}
+		want  interface{}
+	}{
+		{"company number", entry.CompanyNumber, "443061841"},
+		{"legal entity type", entry.LegalEntityType, pb.LEGAL_ENTITY_TYPE_LEGAL_ENTITY_TYPE_INDIVIDUAL},
+		{"title", entry.GetIndividual().GetTitle(), pb.PERSON_TITLE_PERSON_TITLE_MRS},
+		{"last name", entry.GetIndividual().GetName().GetLastName(), "MARTIN"},
+		{"first name", entry.GetIndividual().GetName().GetFirstName(), "Claire"},
+		{"company type", entry.GetIndividual().GetCompanyType(), pb.BUSINESS_ENTITY_TYPE_BUSINESS_ENTITY_TYPE_MICRO_ENTREPRENEUR},
+		{"validity status", entry.ValidityStatus, pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRED},
+		{"region", entry.GetAddress().GetRegion(), "Auvergne-Rhône-Alpes"},
+		{"company", entry.Company == nil, true},
+		{"raw fields", len(entry.RawFields), 0},
+	}
+
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", check.field, check.got, check.want)
+		}
+	}
+}
+
+func TestGetByCompanyNumber(t *testing.T) {
+	client := newFixtureClient()
+
+	// a SIRET, spaced, is looked up by its SIREN
+	for _, input := range []string{"732829320", "732 829 320 00074"} {
+		entry, err := client.GetByCompanyNumber(ctx, input)
+
+		if err != nil {
+			t.Fatalf("GetByCompanyNumber(%q): %v", input, err)
+		}
+
+		if entry.CompanyNumber != "732829320" || entry.RecordId != 1234 {
+			t.Errorf("GetByCompanyNumber(%q) = %s (record %d)", input, entry.CompanyNumber, entry.RecordId)
+		}
+	}
+}
+
+func TestGetByRegistrationNumber(t *testing.T) {
+	entry, err := newFixtureClient().GetByRegistrationNumber(ctx, "evtc 069 180042")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.RegistrationNumber != "EVTC069180042" {
+		t.Errorf("registration number = %q", entry.RegistrationNumber)
+	}
+}
+
+func TestGetByAdvancedSearchInvalidInput(t *testing.T) {
+	// there is no fixture for these, reaching the fetcher would fail
+	inputs := []map[APISearchParams]string{
+		{SearchCompanyNumber: "732829321"},
+		{SearchRegistrationNumber: "VTC075150001"},
+		{SearchPostalCode: "7500"},
+		{SearchCity: "  "},
+	}
+
+	for _, params := range inputs {
+		_, err := newFixtureClient().GetByAdvancedSearch(ctx, params)
+
+		if _, ok := err.(*InvalidInputError); !ok {
+			t.Errorf("GetByAdvancedSearch(%v) error = %v, want an InvalidInputError", params, err)
+		}
+	}
+}
+
+func TestGetByAdvancedSearchNotFound(t *testing.T) {
+	_, err := newFixtureClient().GetByAdvancedSearch(ctx, map[APISearchParams]string{
+		SearchCompanyName: "INCONNU",
+	})
+
+	if err != ErrNotFound {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetByAdvancedSearchLayoutChanged(t *testing.T) {
+	_, err := newFixtureClient().GetByCompanyNumber(ctx, "542065479")
+
+	if _, ok := err.(*LayoutError); !ok {
+		t.Errorf("error = %v, want a LayoutError", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	result, err := newFixtureClient().Search(ctx, map[APISearchParams]string{
+		SearchCity: "Lyon",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.TotalCount != 3 || result.Page != 2 || result.HasMore {
+		t.Errorf("result = total %d, page %d, has more %v", result.TotalCount, result.Page, result.HasMore)
+	}
+
+	var recordIds []int64
+
+	for _, entry := range result.Entries {
+		recordIds = append(recordIds, entry.RecordId)
+	}
+
+	if len(recordIds) != 3 || recordIds[0] != 5678 || recordIds[1] != 9012 || recordIds[2] != 3456 {
+		t.Errorf("record ids = %v, want [5678 9012 3456]", recordIds)
+	}
+}
+
+func TestSearchPage(t *testing.T) {
+	client := newFixtureClient()
+	params := map[APISearchParams]string{SearchCity: "Lyon"}
+
+	first, err := client.SearchPage(ctx, params, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first.Entries) != 2 || !first.HasMore {
+		t.Errorf("page 1 = %d entries, has more %v", len(first.Entries), first.HasMore)
+	}
+
+	second, err := client.SearchPage(ctx, params, 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(second.Entries) != 1 || second.HasMore || second.Entries[0].RecordId != 3456 {
+		t.Errorf("page 2 = %d entries, has more %v", len(second.Entries), second.HasMore)
+	}
+
+	if _, err := client.SearchPage(ctx, params, 0); err != ErrPageOutOfRange {
+		t.Errorf("page 0 error = %v, want ErrPageOutOfRange", err)
+	}
+}
+
+func TestSearchSingleResult(t *testing.T) {
+	result, err := newFixtureClient().Search(ctx, map[APISearchParams]string{
+		SearchCompanyNumber: "732829320",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Entries) != 1 || result.TotalCount != 1 || result.Entries[0].RecordId != 1234 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestGetByAdvancedSearchResultList(t *testing.T) {
+	client := newFixtureClient()
+
+	// several operators match, none of them is "not found"
+	_, err := client.GetByAdvancedSearch(ctx, map[APISearchParams]string{SearchCity: "Lyon"})
+
+	if err != ErrAmbiguous {
+		t.Errorf("several rows: error = %v, want ErrAmbiguous", err)
+	}
+
+	// a single row is resolved through its record
+	entry, err := client.GetByAdvancedSearch(ctx, map[APISearchParams]string{SearchPersonName: "MARTIN"})
+
+	if err != nil {
+		t.Fatalf("single row: %v", err)
+	}
+
+	if entry.RecordId != 5678 || entry.CompanyNumber != "443061841" {
+		t.Errorf("single row = %s (record %d)", entry.CompanyNumber, entry.RecordId)
+	}
+}
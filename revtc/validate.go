@@ -0,0 +1,182 @@
+package revtc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const maxCriterionLength = 100
+
+var (
+	postalCodePattern = regexp.MustCompile(`^\d{5}$`)
+	departmentPattern = regexp.MustCompile(`^(\d{1,3}|2[AB])$`)
+)
+
+var searchParamNames = map[APISearchParams]string{
+	SearchRegistrationNumber: "registration number",
+	SearchCompanyNumber:      "company number",
+	SearchPersonName:         "person name",
+	SearchCompanyName:        "company name",
+	SearchAcronym:            "acronym",
+	SearchBrand:              "brand",
+	SearchCity:               "city",
+	SearchPostalCode:         "postal code",
+	SearchDepartment:         "department",
+}
+
+func (p APISearchParams) String() string {
+	if name, ok := searchParamNames[p]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("APISearchParams(%d)", int(p))
+}
+
+// ValidateSearchParams checks that params holds at least one criterion and
+// that every criterion is well formed.
+func ValidateSearchParams(params map[APISearchParams]string) error {
+	_, err := NormalizeSearchParams(params)
+
+	return err
+}
+
+// NormalizeSearchParams validates params like ValidateSearchParams and
+// returns a copy with trimmed values, and company and registration numbers
+// in the form the registry expects.
+func NormalizeSearchParams(params map[APISearchParams]string) (map[APISearchParams]string, error) {
+	normalized := map[APISearchParams]string{}
+
+	for param, value := range params {
+		if _, ok := searchParamNames[param]; !ok {
+			return nil, &InvalidInputError{Field: "search criterion", Reason: fmt.Sprintf("unknown criterion %d", int(param))}
+		}
+
+		value = strings.TrimSpace(value)
+
+		if value == "" {
+			continue
+		}
+
+		if len(value) > maxCriterionLength {
+			return nil, &InvalidInputError{Field: param.String(), Reason: fmt.Sprintf("must be at most %d characters long", maxCriterionLength)}
+		}
+
+		var err error
+
+		switch param {
+		case SearchCompanyNumber:
+			value, err = NormalizeCompanyNumber(value)
+
+		case SearchRegistrationNumber:
+			value, err = NormalizeRegistrationNumber(value)
+
+		case SearchPostalCode:
+			if !postalCodePattern.MatchString(value) {
+				err = &InvalidInputError{Field: param.String(), Reason: fmt.Sprintf("must be 5 digits, got %q", value)}
+			}
+
+		case SearchDepartment:
+			if !departmentPattern.MatchString(strings.ToUpper(value)) {
+				err = &InvalidInputError{Field: param.String(), Reason: fmt.Sprintf("must be a department number, got %q", value)}
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		normalized[param] = value
+	}
+
+	if len(normalized) == 0 {
+		return nil, &InvalidInputError{Field: "search", Reason: "at least one search criterion is required"}
+	}
+
+	return normalized, nil
+}
+
+var (
+	separatorRemover           = strings.NewReplacer(" ", "", "\t", "", ".", "", "-", "", "\u00a0", "")
+	digitsPattern              = regexp.MustCompile(`^\d+$`)
+	registrationNumberPattern  = regexp.MustCompile(`^EVTC(\d{3}|02[AB])\d{6}$`)
+	laPosteCompanyNumberPrefix = "356000000"
+)
+
+// NormalizeCompanyNumber checks a SIREN, or a SIRET which is reduced to its
+// SIREN, once spaces and dots are removed. Both must pass the Luhn checksum.
+func NormalizeCompanyNumber(value string) (string, error) {
+	number := separatorRemover.Replace(value)
+
+	if !digitsPattern.MatchString(number) {
+		return "", &InvalidInputError{Field: "company number", Reason: fmt.Sprintf("must only contain digits, got %q", value)}
+	}
+
+	switch len(number) {
+	case 9:
+	case 14:
+		// La Poste establishments do not follow Luhn, their digits add up
+		// to a multiple of 5 instead
+		if strings.HasPrefix(number, laPosteCompanyNumberPrefix) {
+			if digitSum(number)%5 != 0 {
+				return "", &InvalidInputError{Field: "company number", Reason: fmt.Sprintf("SIRET %s has an invalid checksum", number)}
+			}
+		} else if !luhnValid(number) {
+			return "", &InvalidInputError{Field: "company number", Reason: fmt.Sprintf("SIRET %s has an invalid checksum", number)}
+		}
+
+		number = number[:9]
+	default:
+		return "", &InvalidInputError{Field: "company number", Reason: fmt.Sprintf("must be a 9 digit SIREN or a 14 digit SIRET, got %d digits", len(number))}
+	}
+
+	if !luhnValid(number) {
+		return "", &InvalidInputError{Field: "company number", Reason: fmt.Sprintf("SIREN %s has an invalid checksum", number)}
+	}
+
+	return number, nil
+}
+
+// NormalizeRegistrationNumber checks an EVTC registration number, made of
+// "EVTC", a 3 character department code and 6 digits, once upper-cased and
+// stripped of spaces, dots and dashes.
+func NormalizeRegistrationNumber(value string) (string, error) {
+	number := strings.ToUpper(separatorRemover.Replace(value))
+
+	if !registrationNumberPattern.MatchString(number) {
+		return "", &InvalidInputError{Field: "registration number", Reason: fmt.Sprintf("must look like EVTC075123456, got %q", value)}
+	}
+
+	return number, nil
+}
+
+func luhnValid(number string) bool {
+	sum := 0
+
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+
+		// every second digit from the right is doubled
+		if (len(number)-i)%2 == 0 {
+			digit *= 2
+
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+func digitSum(number string) int {
+	sum := 0
+
+	for i := range number {
+		sum += int(number[i] - '0')
+	}
+
+	return sum
+}
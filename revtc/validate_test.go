@@ -0,0 +1,139 @@
+package revtc
+
+import "testing"
+
+func TestNormalizeCompanyNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{"732829320", "732829320", true},
+		{"732 829 320", "732829320", true},
+		{"732.829.320", "732829320", true},
+		{"73282932000074", "732829320", true},
+		{"732 829 320 00074", "732829320", true},
+		// La Poste SIRETs only add up to a multiple of 5
+		{"35600000000048", "", false},
+		{"35600000049837", "356000000", true},
+		{"732829321", "", false},
+		{"73282932000075", "", false},
+		{"7328293", "", false},
+		{"73282932A", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		got, err := NormalizeCompanyNumber(test.input)
+
+		if test.valid != (err == nil) || got != test.want {
+			t.Errorf("NormalizeCompanyNumber(%q) = %q, %v", test.input, got, err)
+		}
+
+		if err != nil {
+			if _, ok := err.(*InvalidInputError); !ok {
+				t.Errorf("NormalizeCompanyNumber(%q) error is a %T", test.input, err)
+			}
+		}
+	}
+}
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  IdentifierKind
+		valid bool
+	}{
+		{"732829320", IdentifierCompanyNumber, true},
+		{"732 829 320 00074", IdentifierCompanyNumber, true},
+		{"EVTC075150001", IdentifierRegistrationNumber, true},
+		{"evtc 075 150 001", IdentifierRegistrationNumber, true},
+		// digits of the wrong length are a mistyped SIREN, not a record id
+		{"73282932", 0, false},
+		{"7328293200007", 0, false},
+		{"1234", 0, false},
+		{"ACME", 0, false},
+		{"", 0, false},
+	}
+
+	for _, test := range tests {
+		got, err := ParseIdentifier(test.input)
+
+		if test.valid != (err == nil) || test.valid && got.Kind != test.kind {
+			t.Errorf("ParseIdentifier(%q) = %v, %v", test.input, got, err)
+		}
+
+		if err != nil {
+			if _, ok := err.(*InvalidInputError); !ok {
+				t.Errorf("ParseIdentifier(%q) error is a %T", test.input, err)
+			}
+		}
+	}
+}
+
+func TestNormalizeRegistrationNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{"EVTC075150001", "EVTC075150001", true},
+		{"evtc075150001", "EVTC075150001", true},
+		{"EVTC 075 150 001", "EVTC075150001", true},
+		{"EVTC-02A-123456", "EVTC02A123456", true},
+		{"EVTC974123456", "EVTC974123456", true},
+		{"EVTC75150001", "", false},
+		{"VTC075150001", "", false},
+		{"EVTC0751500012", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		got, err := NormalizeRegistrationNumber(test.input)
+
+		if test.valid != (err == nil) || got != test.want {
+			t.Errorf("NormalizeRegistrationNumber(%q) = %q, %v", test.input, got, err)
+		}
+	}
+}
+
+func TestValidateSearchParams(t *testing.T) {
+	valid := []map[APISearchParams]string{
+		{SearchCity: "Lyon"},
+		{SearchDepartment: "2a"},
+		{SearchPostalCode: " 69002 ", SearchCity: ""},
+	}
+
+	for _, params := range valid {
+		if err := ValidateSearchParams(params); err != nil {
+			t.Errorf("ValidateSearchParams(%v) = %v", params, err)
+		}
+	}
+
+	invalid := []map[APISearchParams]string{
+		{},
+		{SearchCity: " "},
+		{SearchPostalCode: "6900"},
+		{SearchDepartment: "Rhône"},
+		{APISearchParams(42): "x"},
+	}
+
+	for _, params := range invalid {
+		if err := ValidateSearchParams(params); err == nil {
+			t.Errorf("ValidateSearchParams(%v) = nil, want an error", params)
+		}
+	}
+}
+
+func TestNormalizeSearchParamsDoesNotModifyInput(t *testing.T) {
+	params := map[APISearchParams]string{SearchCompanyNumber: " 732 829 320 "}
+	normalized, err := NormalizeSearchParams(params)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if normalized[SearchCompanyNumber] != "732829320" || params[SearchCompanyNumber] != " 732 829 320 " {
+		t.Errorf("normalized = %q, params = %q", normalized[SearchCompanyNumber], params[SearchCompanyNumber])
+	}
+}
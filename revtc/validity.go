@@ -0,0 +1,72 @@
+package revtc
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"time"
+	// Europe/Paris must resolve on hosts without a tz database
+	_ "time/tzdata"
+)
+
+// DefaultExpiryWarning is how long before its expiration date an entry is
+// reported as expiring.
+const DefaultExpiryWarning = 30 * 24 * time.Hour
+
+const registryDateLayout = "02/01/2006"
+
+// registryLocation is the time zone dates on the registry are expressed in.
+var registryLocation = loadRegistryLocation()
+
+func loadRegistryLocation() *time.Location {
+	location, err := time.LoadLocation("Europe/Paris")
+
+	// the tz database is embedded, this only fails on a broken toolchain
+	if err != nil {
+		panic(err)
+	}
+
+	return location
+}
+
+// WithExpiryWarning sets how long before its expiration date an entry is
+// reported as VALIDITY_STATUS_EXPIRING.
+func WithExpiryWarning(warning time.Duration) Option {
+	return func(c *Client) {
+		c.expiryWarning = warning
+	}
+}
+
+// parseRegistryDate reads a registry date as the start of that day in Paris.
+func parseRegistryDate(value string) (time.Time, error) {
+	return time.ParseInLocation(registryDateLayout, value, registryLocation)
+}
+
+// ValidityStatus tells whether entry is still registered at now. An entry
+// stays valid until the end of its expiration day, and is expiring when
+// that day is less than warning away.
+func ValidityStatus(entry *pb.VTCEntry, now time.Time, warning time.Duration) pb.VALIDITY_STATUS {
+	if entry.ExpirationDate == nil {
+		return pb.VALIDITY_STATUS_VALIDITY_STATUS_UNKNOWN
+	}
+
+	expirationDate, err := ptypes.Timestamp(entry.ExpirationDate)
+
+	if err != nil {
+		return pb.VALIDITY_STATUS_VALIDITY_STATUS_UNKNOWN
+	}
+
+	end := expirationDate.In(registryLocation).AddDate(0, 0, 1)
+
+	switch {
+	case !now.Before(end):
+		return pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRED
+	case end.Sub(now) <= warning:
+		return pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRING
+	}
+
+	return pb.VALIDITY_STATUS_VALIDITY_STATUS_VALID
+}
+
+func (c *Client) setValidityStatus(entry *pb.VTCEntry) {
+	entry.ValidityStatus = ValidityStatus(entry, time.Now(), c.expiryWarning)
+}
@@ -0,0 +1,62 @@
+package revtc
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"testing"
+	"time"
+)
+
+func TestValidityStatus(t *testing.T) {
+	paris := func(value string) time.Time {
+		date, err := time.ParseInLocation("02/01/2006 15:04:05", value, registryLocation)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return date
+	}
+
+	const warning = 30 * 24 * time.Hour
+
+	tests := []struct {
+		now        string
+		expiration string
+		want       pb.VALIDITY_STATUS
+	}{
+		{"01/03/2030 10:00:00", "", pb.VALIDITY_STATUS_VALIDITY_STATUS_UNKNOWN},
+		{"01/03/2030 10:00:00", "28/02/2030", pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRED},
+		// an entry is valid until the end of its expiration day
+		{"01/03/2030 10:00:00", "01/03/2030", pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRING},
+		{"01/03/2030 23:59:59", "01/03/2030", pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRING},
+		{"02/03/2030 00:00:00", "01/03/2030", pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRED},
+		// the end of the expiration day is exactly the warning away
+		{"01/03/2030 00:00:00", "30/03/2030", pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRING},
+		// one day further, across the switch to summer time
+		{"01/03/2030 00:00:00", "31/03/2030", pb.VALIDITY_STATUS_VALIDITY_STATUS_VALID},
+		{"01/03/2030 10:00:00", "31/03/2030", pb.VALIDITY_STATUS_VALIDITY_STATUS_VALID},
+		{"01/03/2030 10:00:00", "01/03/2031", pb.VALIDITY_STATUS_VALIDITY_STATUS_VALID},
+	}
+
+	for _, test := range tests {
+		entry := &pb.VTCEntry{}
+
+		if test.expiration != "" {
+			expiration, err := parseRegistryDate(test.expiration)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry.ExpirationDate, _ = ptypes.TimestampProto(expiration)
+		}
+
+		// the caller's time zone does not matter
+		now := paris(test.now).UTC()
+
+		if got := ValidityStatus(entry, now, warning); got != test.want {
+			t.Errorf("ValidityStatus(%q) at %s = %v, want %v", test.expiration, test.now, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,557 @@
+package revtc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/protobuf/jsonpb"
+	pb "github.com/united-drivers/go-revtc/proto"
+	"golang.org/x/time/rate"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWatchInterval is how often a watched operator is re-checked.
+	DefaultWatchInterval = 24 * time.Hour
+	// DefaultWatchJitter spreads re-checks by up to this much either way so
+	// that operators added together are not all checked at once.
+	DefaultWatchJitter = time.Hour
+	// DefaultWatchRateLimit is the number of re-checks per second, kept well
+	// below the client rate limit to leave room for live lookups.
+	DefaultWatchRateLimit = 0.2
+	// DefaultWatchRetryDelay is how soon a check that failed is tried again;
+	// it doubles on every consecutive failure, up to the watch interval.
+	DefaultWatchRetryDelay = time.Minute
+)
+
+// fields that change on every fetch and are not reported as changes
+var unwatchedFields = map[string]bool{
+	"fetched_at":      true,
+	"from_cache":      true,
+	"validity_status": true,
+}
+
+// WatchEventType tells what happened to a watched operator.
+type WatchEventType int
+
+const (
+	// WatchEntryRemoved is fired when a previously found operator is no
+	// longer in the registry.
+	WatchEntryRemoved WatchEventType = iota
+	// WatchEntryRestored is fired when a removed operator is found again.
+	WatchEntryRestored
+	// WatchEntryExpiring is fired when the registration enters the expiry
+	// warning window.
+	WatchEntryExpiring
+	// WatchEntryExpired is fired when the registration expiration date has
+	// passed.
+	WatchEntryExpired
+	// WatchEntryChanged is fired when any field of the entry changed.
+	WatchEntryChanged
+)
+
+func (t WatchEventType) String() string {
+	switch t {
+	case WatchEntryRemoved:
+		return "removed"
+	case WatchEntryRestored:
+		return "restored"
+	case WatchEntryExpiring:
+		return "expiring"
+	case WatchEntryExpired:
+		return "expired"
+	case WatchEntryChanged:
+		return "changed"
+	}
+
+	return fmt.Sprintf("WatchEventType(%d)", int(t))
+}
+
+// MarshalText renders t as its name in JSON documents.
+func (t WatchEventType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// FieldChange is a field of an entry that changed between two checks. Field
+// is the dotted path of the field in the JSON rendering of the entry.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// WatchEvent is a notable change of a watched operator. Entry is the latest
+// known entry, which is the last one found for WatchEntryRemoved.
+type WatchEvent struct {
+	Type       WatchEventType `json:"type"`
+	Identifier string         `json:"identifier"`
+	At         time.Time      `json:"at"`
+	Entry      *pb.VTCEntry   `json:"entry,omitempty"`
+	Changes    []FieldChange  `json:"changes,omitempty"`
+}
+
+// MarshalJSON renders Entry through jsonpb, like the entries changes are
+// computed from, so that every change field is a path into entry and holds
+// a value of the same form.
+func (e WatchEvent) MarshalJSON() ([]byte, error) {
+	// watchEvent has the fields of WatchEvent but not this method
+	type watchEvent WatchEvent
+
+	var entry json.RawMessage
+
+	if e.Entry != nil {
+		rendered, err := renderEntry(e.Entry)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entry = json.RawMessage(rendered)
+	}
+
+	return json.Marshal(struct {
+		watchEvent
+		Entry json.RawMessage `json:"entry,omitempty"`
+	}{watchEvent(e), entry})
+}
+
+// WatchStatus describes a watched operator as of its last check.
+type WatchStatus struct {
+	Identifier string       `json:"identifier"`
+	Kind       string       `json:"kind"`
+	Entry      *pb.VTCEntry `json:"entry,omitempty"`
+	Removed    bool         `json:"removed"`
+	LastCheck  time.Time    `json:"last_check"`
+	NextCheck  time.Time    `json:"next_check"`
+	LastError  string       `json:"last_error,omitempty"`
+}
+
+// WatchOption configures a Watchlist.
+type WatchOption func(*Watchlist)
+
+// WithWatchInterval sets how often operators are re-checked, each check
+// being moved by a random duration of up to jitter either way.
+func WithWatchInterval(interval time.Duration, jitter time.Duration) WatchOption {
+	return func(w *Watchlist) {
+		w.interval = interval
+		w.jitter = jitter
+	}
+}
+
+// WithWatchRetry sets how soon a check failing with anything but
+// ErrNotFound is tried again. The delay doubles on every consecutive failure
+// and never exceeds the watch interval.
+func WithWatchRetry(delay time.Duration) WatchOption {
+	return func(w *Watchlist) {
+		w.retryDelay = delay
+	}
+}
+
+// WithWatchRateLimit caps the re-checks per second. A zero perSecond only
+// leaves the client rate limit.
+func WithWatchRateLimit(perSecond float64) WatchOption {
+	return func(w *Watchlist) {
+		w.rateLimit = perSecond
+	}
+}
+
+// Watchlist periodically re-fetches a set of operators, bypassing the
+// cache, and reports removals, expirations and changes to its subscribers.
+type Watchlist struct {
+	client     *Client
+	interval   time.Duration
+	jitter     time.Duration
+	retryDelay time.Duration
+	rateLimit  float64
+	limiter    *rate.Limiter
+
+	mu       sync.Mutex
+	items    map[string]*watchedItem
+	handlers []func(WatchEvent)
+	wake     chan struct{}
+}
+
+type watchedItem struct {
+	id        Identifier
+	entry     *pb.VTCEntry
+	removed   bool
+	lastCheck time.Time
+	nextCheck time.Time
+	lastErr   error
+	// failures counts the checks that failed in a row
+	failures int
+}
+
+// NewWatchlist returns an empty watchlist checking operators through client.
+// Checks only happen while Run is running.
+func NewWatchlist(client *Client, opts ...WatchOption) *Watchlist {
+	w := &Watchlist{
+		client:     client,
+		interval:   DefaultWatchInterval,
+		jitter:     DefaultWatchJitter,
+		retryDelay: DefaultWatchRetryDelay,
+		rateLimit:  DefaultWatchRateLimit,
+		items:      map[string]*watchedItem{},
+		wake:       make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if w.rateLimit > 0 {
+		w.limiter = rate.NewLimiter(rate.Limit(w.rateLimit), 1)
+	}
+
+	return w
+}
+
+// Subscribe calls handler with every event. Handlers are called from the
+// scheduler goroutine and should not block.
+func (w *Watchlist) Subscribe(handler func(WatchEvent)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers = append(w.handlers, handler)
+}
+
+// watchKey validates a SIREN, SIRET or registration number and returns it
+// in normalized form.
+func watchKey(identifier string) (Identifier, error) {
+	id, err := ParseIdentifier(identifier)
+
+	if err != nil {
+		return Identifier{}, err
+	}
+
+	switch id.Kind {
+	case IdentifierCompanyNumber:
+		id.Value, err = NormalizeCompanyNumber(id.Value)
+	case IdentifierRegistrationNumber:
+		id.Value, err = NormalizeRegistrationNumber(id.Value)
+	default:
+		err = &InvalidInputError{Field: "identifier", Reason: "only SIRENs and registration numbers can be watched"}
+	}
+
+	return id, err
+}
+
+// Add watches the operator with the given SIREN, SIRET or registration
+// number and returns its normalized identifier. New operators are checked
+// right away to record their current state.
+func (w *Watchlist) Add(identifier string) (string, error) {
+	id, err := watchKey(identifier)
+
+	if err != nil {
+		return "", err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.items[id.Value]; !ok {
+		w.items[id.Value] = &watchedItem{id: id, nextCheck: time.Now()}
+		w.notify()
+	}
+
+	return id.Value, nil
+}
+
+// Remove stops watching an operator and tells whether it was watched.
+func (w *Watchlist) Remove(identifier string) bool {
+	id, err := watchKey(identifier)
+
+	if err != nil {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, ok := w.items[id.Value]
+	delete(w.items, id.Value)
+
+	return ok
+}
+
+// Items returns the watched operators ordered by identifier.
+func (w *Watchlist) Items() []WatchStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	statuses := make([]WatchStatus, 0, len(w.items))
+
+	for key, item := range w.items {
+		status := WatchStatus{
+			Identifier: key,
+			Kind:       item.id.Kind.String(),
+			Entry:      item.entry,
+			Removed:    item.removed,
+			LastCheck:  item.lastCheck,
+			NextCheck:  item.nextCheck,
+		}
+
+		if item.lastErr != nil {
+			status.LastError = item.lastErr.Error()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Identifier < statuses[j].Identifier
+	})
+
+	return statuses
+}
+
+// notify wakes Run up so that it picks newly added operators; w.mu must be
+// held.
+func (w *Watchlist) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run checks operators as they become due until ctx is done.
+func (w *Watchlist) Run(ctx context.Context) error {
+	for {
+		key, due := w.nextDue()
+		timer := time.NewTimer(time.Until(due))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-w.wake:
+			timer.Stop()
+
+			continue
+		case <-timer.C:
+		}
+
+		if key == "" {
+			continue
+		}
+
+		if w.limiter != nil {
+			if err := w.limiter.Wait(ctx); err != nil {
+				return ctx.Err()
+			}
+		}
+
+		w.check(ctx, key)
+	}
+}
+
+// nextDue returns the operator to check next and when. With nothing to
+// watch it returns an empty key and a time far enough to only wake up on
+// Add.
+func (w *Watchlist) nextDue() (string, time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key, due := "", time.Now().Add(w.interval)
+
+	for k, item := range w.items {
+		if key == "" || item.nextCheck.Before(due) {
+			key, due = k, item.nextCheck
+		}
+	}
+
+	return key, due
+}
+
+func (w *Watchlist) nextCheck(now time.Time) time.Time {
+	next := now.Add(w.interval)
+
+	if w.jitter > 0 {
+		next = next.Add(time.Duration(rand.Int63n(int64(2*w.jitter))) - w.jitter)
+	}
+
+	return next
+}
+
+// nextRetry returns when to check again after the given number of failed
+// checks in a row.
+func (w *Watchlist) nextRetry(now time.Time, failures int) time.Time {
+	return now.Add(BackoffDelay(w.retryDelay, w.interval, failures))
+}
+
+func (w *Watchlist) refresh(ctx context.Context, id Identifier) (pb.VTCEntry, error) {
+	param := SearchCompanyNumber
+
+	if id.Kind == IdentifierRegistrationNumber {
+		param = SearchRegistrationNumber
+	}
+
+	return w.client.RefreshByAdvancedSearch(ctx, map[APISearchParams]string{param: id.Value})
+}
+
+// check re-fetches an operator and fires the events its new state calls for.
+func (w *Watchlist) check(ctx context.Context, key string) {
+	w.mu.Lock()
+	item, ok := w.items[key]
+	w.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	entry, err := w.refresh(ctx, item.id)
+	now := time.Now()
+
+	w.mu.Lock()
+
+	if item != w.items[key] {
+		// removed while being checked
+		w.mu.Unlock()
+
+		return
+	}
+
+	item.lastCheck = now
+	item.nextCheck = w.nextCheck(now)
+	item.lastErr = nil
+
+	if err != nil && err != ErrNotFound {
+		// the registry may be back long before the next regular check
+		item.failures++
+		item.nextCheck = w.nextRetry(now, item.failures)
+	} else {
+		item.failures = 0
+	}
+
+	var events []WatchEvent
+
+	event := func(eventType WatchEventType, entry *pb.VTCEntry) *WatchEvent {
+		events = append(events, WatchEvent{Type: eventType, Identifier: key, At: now, Entry: entry})
+
+		return &events[len(events)-1]
+	}
+
+	switch {
+	case err == ErrNotFound:
+		if item.entry != nil && !item.removed {
+			event(WatchEntryRemoved, item.entry)
+		}
+
+		item.removed = true
+
+	case err != nil:
+		item.lastErr = err
+
+	default:
+		previousStatus := pb.VALIDITY_STATUS_VALIDITY_STATUS_UNKNOWN
+
+		if item.entry != nil {
+			previousStatus = item.entry.ValidityStatus
+
+			if item.removed {
+				event(WatchEntryRestored, &entry)
+			}
+
+			if changes := diffEntries(item.entry, &entry); len(changes) > 0 {
+				event(WatchEntryChanged, &entry).Changes = changes
+			}
+		}
+
+		if entry.ValidityStatus != previousStatus {
+			switch entry.ValidityStatus {
+			case pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRING:
+				event(WatchEntryExpiring, &entry)
+			case pb.VALIDITY_STATUS_VALIDITY_STATUS_EXPIRED:
+				event(WatchEntryExpired, &entry)
+			}
+		}
+
+		item.entry = &entry
+		item.removed = false
+	}
+
+	handlers := w.handlers
+	w.mu.Unlock()
+
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+// diffEntries lists the fields that differ between two entries, comparing
+// their renderEntry renderings leaf by leaf.
+func diffEntries(previous *pb.VTCEntry, current *pb.VTCEntry) []FieldChange {
+	oldFields, newFields := flattenEntry(previous), flattenEntry(current)
+	changes := []FieldChange{}
+
+	for field, oldValue := range oldFields {
+		if newValue, ok := newFields[field]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newFields[field]})
+		}
+	}
+
+	for field, newValue := range newFields {
+		if _, ok := oldFields[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, New: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+// renderEntry renders entry the way watch events show it: the proto field
+// names, enums by name and timestamps in RFC 3339.
+func renderEntry(entry *pb.VTCEntry) (string, error) {
+	marshaler := jsonpb.Marshaler{OrigName: true}
+
+	return marshaler.MarshalToString(entry)
+}
+
+func flattenEntry(entry *pb.VTCEntry) map[string]interface{} {
+	fields := map[string]interface{}{}
+	rendered, err := renderEntry(entry)
+
+	if err != nil {
+		return fields
+	}
+
+	var document map[string]interface{}
+
+	if err := json.Unmarshal([]byte(rendered), &document); err != nil {
+		return fields
+	}
+
+	flatten("", document, fields)
+
+	return fields
+}
+
+func flatten(prefix string, document map[string]interface{}, fields map[string]interface{}) {
+	for key, value := range document {
+		if prefix == "" && unwatchedFields[key] {
+			continue
+		}
+
+		path := strings.TrimPrefix(prefix+"."+key, ".")
+
+		if object, ok := value.(map[string]interface{}); ok {
+			flatten(path, object, fields)
+
+			continue
+		}
+
+		fields[path] = value
+	}
+}
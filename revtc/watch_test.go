@@ -0,0 +1,213 @@
+package revtc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statusFetcher answers every request with the same HTTP status.
+type statusFetcher int
+
+func (f statusFetcher) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: int(f),
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+// editedFetcher serves the pages of testdata through edit, or the no-result
+// page to every search when removed is set.
+type editedFetcher struct {
+	edit    func(page string) string
+	removed bool
+}
+
+func (f *editedFetcher) Do(req *http.Request) (*http.Response, error) {
+	name, err := FixtureName(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if f.removed {
+		name = "rechercheExploitant.avancee.action_nomDenomination_INCONNU.html"
+	}
+
+	page, err := ioutil.ReadFile(filepath.Join("testdata", name))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := string(page)
+
+	if f.edit != nil {
+		body = f.edit(body)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+// expiringOn moves the expiration date of the 1234 detail page.
+func expiringOn(date time.Time) func(string) string {
+	return strings.NewReplacer(
+		"01/03/2030", date.In(registryLocation).Format(registryDateLayout),
+		"Nombre de véhicules</label> 12", "Nombre de véhicules</label> 13",
+	).Replace
+}
+
+func TestWatchlistEvents(t *testing.T) {
+	fetcher := &editedFetcher{}
+	w := NewWatchlist(newFixtureClient(WithFetcher(fetcher)))
+
+	var events []WatchEvent
+
+	w.Subscribe(func(event WatchEvent) {
+		events = append(events, event)
+	})
+
+	key, err := w.Add("732 829 320")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	moreVehicles := strings.NewReplacer("Nombre de véhicules</label> 12", "Nombre de véhicules</label> 13").Replace
+
+	steps := []struct {
+		name    string
+		edit    func(string) string
+		removed bool
+		want    []WatchEventType
+	}{
+		{"first check", nil, false, nil},
+		{"unchanged", nil, false, nil},
+		{"changed", moreVehicles, false, []WatchEventType{WatchEntryChanged}},
+		{"expiring", expiringOn(now.AddDate(0, 0, 10)), false, []WatchEventType{WatchEntryChanged, WatchEntryExpiring}},
+		{"still expiring", expiringOn(now.AddDate(0, 0, 10)), false, nil},
+		{"expired", expiringOn(now.AddDate(0, 0, -1)), false, []WatchEventType{WatchEntryChanged, WatchEntryExpired}},
+		{"removed", nil, true, []WatchEventType{WatchEntryRemoved}},
+		{"still removed", nil, true, nil},
+		{"restored", expiringOn(now.AddDate(0, 0, -1)), false, []WatchEventType{WatchEntryRestored}},
+		{"renewed", nil, false, []WatchEventType{WatchEntryChanged}},
+	}
+
+	for _, step := range steps {
+		fetcher.edit, fetcher.removed = step.edit, step.removed
+		events = nil
+
+		w.check(ctx, key)
+
+		var got []WatchEventType
+
+		for _, event := range events {
+			got = append(got, event.Type)
+
+			if event.Identifier != key || event.Entry == nil {
+				t.Errorf("%s: %s event for %q with entry %v", step.name, event.Type, event.Identifier, event.Entry)
+			}
+		}
+
+		if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: events %v, want %v", step.name, got, step.want)
+		}
+
+		if item := w.Items()[0]; item.LastError != "" || item.Removed != step.removed {
+			t.Errorf("%s: removed = %v, error %q", step.name, item.Removed, item.LastError)
+		}
+	}
+}
+
+func TestWatchEventJSON(t *testing.T) {
+	fetcher := &editedFetcher{}
+	w := NewWatchlist(newFixtureClient(WithFetcher(fetcher)))
+
+	var events []WatchEvent
+
+	w.Subscribe(func(event WatchEvent) {
+		events = append(events, event)
+	})
+
+	key, _ := w.Add("732829320")
+	w.check(ctx, key)
+
+	fetcher.edit = expiringOn(time.Date(2031, time.June, 15, 0, 0, 0, 0, registryLocation))
+	w.check(ctx, key)
+
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	payload, err := json.Marshal(events[0])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		Type    string                 `json:"type"`
+		Entry   map[string]interface{} `json:"entry"`
+		Changes []FieldChange          `json:"changes"`
+	}
+
+	if err := json.Unmarshal(payload, &document); err != nil {
+		t.Fatal(err)
+	}
+
+	if document.Type != "changed" || document.Entry["legal_entity_type"] != "LEGAL_ENTITY_TYPE_COMPANY" {
+		t.Errorf("event rendered as %s", payload)
+	}
+
+	if len(document.Changes) == 0 {
+		t.Fatalf("no changes in %s", payload)
+	}
+
+	// every change can be read back from the entry of the same event
+	for _, change := range document.Changes {
+		var value interface{} = document.Entry
+
+		for _, key := range strings.Split(change.Field, ".") {
+			value = value.(map[string]interface{})[key]
+		}
+
+		if value != change.New {
+			t.Errorf("change %s = %v, entry holds %v", change.Field, change.New, value)
+		}
+	}
+}
+
+func TestWatchlistRetriesFailedChecks(t *testing.T) {
+	client := newFixtureClient(WithFetcher(statusFetcher(http.StatusBadGateway)))
+	w := NewWatchlist(client, WithWatchRetry(time.Minute))
+
+	key, err := w.Add("732829320")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the delay doubles on every failure, with up to 50% jitter
+	for failures := 1; failures <= 3; failures++ {
+		w.check(ctx, key)
+
+		item := w.Items()[0]
+		delay := item.NextCheck.Sub(item.LastCheck)
+		max := time.Minute << uint(failures-1)
+
+		if item.LastError == "" || delay < max/2 || delay > max {
+			t.Errorf("after %d failures: next check in %v (error %q), want between %v and %v", failures, delay, item.LastError, max/2, max)
+		}
+	}
+}
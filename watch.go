@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/united-drivers/go-revtc/revtc"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const defaultWatchEventHistory = 1000
+
+var watchlist *revtc.Watchlist
+
+// watchEvents keeps the latest watchlist events for GET /watchlist/events.
+var watchEvents = &eventHistory{size: defaultWatchEventHistory}
+
+type eventHistory struct {
+	mu     sync.Mutex
+	size   int
+	events []revtc.WatchEvent
+}
+
+func (h *eventHistory) add(event revtc.WatchEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.events = append(h.events, event)
+
+	if len(h.events) > h.size {
+		h.events = h.events[len(h.events)-h.size:]
+	}
+}
+
+func (h *eventHistory) list() []revtc.WatchEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]revtc.WatchEvent{}, h.events...)
+}
+
+// startWatchlist creates the watchlist, loads the operators saved in
+// WATCHLIST_FILE and starts checking them.
+func startWatchlist() {
+	watchlist = revtc.NewWatchlist(client, watchOptions()...)
+
+	watchlist.Subscribe(func(event revtc.WatchEvent) {
+		log.Printf("watchlist: %s %s", event.Identifier, event.Type)
+		watchEvents.add(event)
+	})
+
+	if path := os.Getenv("WATCHLIST_FILE"); path != "" {
+		if err := loadWatchlist(path); err != nil && !os.IsNotExist(err) {
+			log.Fatalf("watchlist: %v", err)
+		}
+	}
+
+	go watchlist.Run(context.Background())
+}
+
+// loadWatchlist adds the identifiers listed in path, one per line.
+func loadWatchlist(path string) error {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if _, err := watchlist.Add(line); err != nil {
+			log.Printf("watchlist: skipping %q: %v", line, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// saveWatchlist writes the watched identifiers to WATCHLIST_FILE, if set.
+func saveWatchlist() {
+	path := os.Getenv("WATCHLIST_FILE")
+
+	if path == "" {
+		return
+	}
+
+	var lines []string
+
+	for _, item := range watchlist.Items() {
+		lines = append(lines, item.Identifier+"\n")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".watchlist-")
+
+	if err != nil {
+		log.Printf("watchlist: %v", err)
+
+		return
+	}
+
+	_, err = tmp.WriteString(strings.Join(lines, ""))
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		log.Printf("watchlist: %v", err)
+	}
+}
+
+func httpListWatchlist(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"items": watchlist.Items()})
+}
+
+func httpAddToWatchlist(c *gin.Context) {
+	identifier, err := watchlist.Add(c.Param("identifier"))
+
+	if err != nil {
+		httpError(c, err)
+
+		return
+	}
+
+	saveWatchlist()
+
+	c.JSON(http.StatusOK, gin.H{"identifier": identifier})
+}
+
+func httpRemoveFromWatchlist(c *gin.Context) {
+	if !watchlist.Remove(c.Param("identifier")) {
+		httpError(c, revtc.ErrNotFound)
+
+		return
+	}
+
+	saveWatchlist()
+
+	c.Status(http.StatusNoContent)
+}
+
+func httpWatchlistEvents(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"events": watchEvents.list()})
+}
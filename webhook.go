@@ -0,0 +1,403 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/united-drivers/go-revtc/revtc"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWebhookMaxAttempts   = 6
+	defaultWebhookRetryDelay    = 5 * time.Second
+	defaultWebhookRetryMaxDelay = 10 * time.Minute
+	defaultWebhookTimeout       = 10 * time.Second
+	defaultWebhookQueueSize     = 1000
+	defaultWebhookConcurrency   = 2
+
+	webhookSignatureHeader = "X-Revtc-Signature"
+	webhookTimestampHeader = "X-Revtc-Timestamp"
+	webhookDeliveryHeader  = "X-Revtc-Delivery"
+	webhookEventHeader     = "X-Revtc-Event"
+)
+
+var webhooks *webhookDispatcher
+
+// webhookDelivery is a watchlist event on its way to one webhook URL.
+type webhookDelivery struct {
+	ID        string          `json:"id"`
+	URL       string          `json:"url"`
+	Event     string          `json:"event"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	FailedAt  time.Time       `json:"failed_at,omitempty"`
+}
+
+// webhookDispatcher posts watchlist events to the configured URLs, retrying
+// failed deliveries with exponential backoff. Workers only make one attempt
+// at a time; retries are queued again once their backoff elapses, so that a
+// failing URL does not hold up the others. Deliveries that exhaust their
+// attempts are kept as dead letters, appended to deadLetterPath when set,
+// until they are replayed.
+type webhookDispatcher struct {
+	urls           []string
+	secret         []byte
+	maxAttempts    int
+	retryDelay     time.Duration
+	retryMaxDelay  time.Duration
+	httpClient     *http.Client
+	queue          chan *webhookDelivery
+	deadLetterPath string
+
+	mu          sync.Mutex
+	deadLetters map[string]*webhookDelivery
+}
+
+// startWebhooks sends watchlist events to WEBHOOK_URLS, if any.
+func startWebhooks() {
+	urls := strings.FieldsFunc(os.Getenv("WEBHOOK_URLS"), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	if len(urls) == 0 {
+		return
+	}
+
+	secret := os.Getenv("WEBHOOK_SECRET")
+
+	// receivers could not tell unsigned deliveries from forged ones
+	if secret == "" {
+		log.Fatal("webhook: WEBHOOK_SECRET is required when WEBHOOK_URLS is set")
+	}
+
+	webhooks = &webhookDispatcher{
+		urls:           urls,
+		secret:         []byte(secret),
+		maxAttempts:    envInt("WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts),
+		retryDelay:     envDuration("WEBHOOK_RETRY_DELAY", defaultWebhookRetryDelay),
+		retryMaxDelay:  envDuration("WEBHOOK_RETRY_MAX_DELAY", defaultWebhookRetryMaxDelay),
+		httpClient:     &http.Client{Timeout: envDuration("WEBHOOK_TIMEOUT", defaultWebhookTimeout)},
+		queue:          make(chan *webhookDelivery, envInt("WEBHOOK_QUEUE_SIZE", defaultWebhookQueueSize)),
+		deadLetterPath: os.Getenv("WEBHOOK_DEAD_LETTER_FILE"),
+		deadLetters:    map[string]*webhookDelivery{},
+	}
+
+	if err := webhooks.loadDeadLetters(); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("webhook: %v", err)
+	}
+
+	for i := 0; i < envInt("WEBHOOK_CONCURRENCY", defaultWebhookConcurrency); i++ {
+		go webhooks.work()
+	}
+
+	watchlist.Subscribe(webhooks.publish)
+}
+
+func newDeliveryID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// publish queues event for every URL. It never blocks: when the queue is
+// full the delivery goes straight to the dead letters.
+func (d *webhookDispatcher) publish(event revtc.WatchEvent) {
+	payload, err := json.Marshal(event)
+
+	if err != nil {
+		log.Printf("webhook: cannot encode %s event: %v", event.Type, err)
+
+		return
+	}
+
+	for _, url := range d.urls {
+		d.enqueue(&webhookDelivery{
+			ID:      newDeliveryID(),
+			URL:     url,
+			Event:   event.Type.String(),
+			Payload: payload,
+		})
+	}
+}
+
+func (d *webhookDispatcher) enqueue(delivery *webhookDelivery) {
+	select {
+	case d.queue <- delivery:
+	default:
+		delivery.LastError = "delivery queue is full"
+		d.deadLetter(delivery)
+	}
+}
+
+func (d *webhookDispatcher) work() {
+	for delivery := range d.queue {
+		d.deliver(delivery)
+	}
+}
+
+// deliver makes one attempt at posting delivery, and schedules the next one
+// unless it succeeded, failed permanently or ran out of attempts.
+func (d *webhookDispatcher) deliver(delivery *webhookDelivery) {
+	delivery.Attempts++
+
+	retry, err := d.post(delivery)
+
+	if err == nil {
+		return
+	}
+
+	delivery.LastError = err.Error()
+
+	if !retry || delivery.Attempts >= d.maxAttempts {
+		d.deadLetter(delivery)
+
+		return
+	}
+
+	time.AfterFunc(revtc.BackoffDelay(d.retryDelay, d.retryMaxDelay, delivery.Attempts), func() {
+		d.enqueue(delivery)
+	})
+}
+
+// sign returns the hex HMAC-SHA256 of "timestamp.payload" under secret.
+func sign(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// post sends delivery once and tells whether a failure is worth retrying.
+func (d *webhookDispatcher) post(delivery *webhookDelivery) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, delivery.Event)
+	req.Header.Set(webhookDeliveryHeader, delivery.ID)
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookSignatureHeader, "sha256="+sign(d.secret, timestamp, delivery.Payload))
+
+	res, err := d.httpClient.Do(req)
+
+	if err != nil {
+		return true, err
+	}
+
+	io.Copy(ioutil.Discard, io.LimitReader(res.Body, 64<<10))
+	res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+
+	err = fmt.Errorf("webhook responded %s", res.Status)
+
+	// other client errors will fail the same way on every attempt
+	retry := res.StatusCode >= 500 || res.StatusCode == http.StatusRequestTimeout || res.StatusCode == http.StatusTooManyRequests
+
+	return retry, err
+}
+
+func (d *webhookDispatcher) deadLetter(delivery *webhookDelivery) {
+	log.Printf("webhook: giving up on delivery %s to %s after %d attempts: %s", delivery.ID, delivery.URL, delivery.Attempts, delivery.LastError)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delivery.FailedAt = time.Now()
+	d.deadLetters[delivery.ID] = delivery
+
+	if d.deadLetterPath == "" {
+		return
+	}
+
+	line, err := json.Marshal(delivery)
+
+	if err != nil {
+		return
+	}
+
+	file, err := os.OpenFile(d.deadLetterPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		log.Printf("webhook: %v", err)
+
+		return
+	}
+
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		log.Printf("webhook: %v", err)
+	}
+}
+
+// loadDeadLetters reads the dead letter log left by a previous run.
+func (d *webhookDispatcher) loadDeadLetters() error {
+	if d.deadLetterPath == "" {
+		return nil
+	}
+
+	file, err := os.Open(d.deadLetterPath)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16<<20)
+
+	for scanner.Scan() {
+		var delivery webhookDelivery
+
+		if err := json.Unmarshal(scanner.Bytes(), &delivery); err != nil || delivery.ID == "" {
+			continue
+		}
+
+		d.deadLetters[delivery.ID] = &delivery
+	}
+
+	return scanner.Err()
+}
+
+// rewriteDeadLetters replaces the dead letter log with the pending dead
+// letters; d.mu must be held.
+func (d *webhookDispatcher) rewriteDeadLetters() {
+	if d.deadLetterPath == "" {
+		return
+	}
+
+	var buffer bytes.Buffer
+
+	for _, delivery := range d.sortedDeadLetters() {
+		line, _ := json.Marshal(delivery)
+		buffer.Write(append(line, '\n'))
+	}
+
+	if err := ioutil.WriteFile(d.deadLetterPath, buffer.Bytes(), 0644); err != nil {
+		log.Printf("webhook: %v", err)
+	}
+}
+
+// sortedDeadLetters returns the dead letters oldest first; d.mu must be held.
+func (d *webhookDispatcher) sortedDeadLetters() []*webhookDelivery {
+	deliveries := make([]*webhookDelivery, 0, len(d.deadLetters))
+
+	for _, delivery := range d.deadLetters {
+		deliveries = append(deliveries, delivery)
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].FailedAt.Before(deliveries[j].FailedAt)
+	})
+
+	return deliveries
+}
+
+// replay queues dead letters again with fresh attempts, all of them when no
+// id is given. It returns the replayed deliveries.
+func (d *webhookDispatcher) replay(ids ...string) []*webhookDelivery {
+	d.mu.Lock()
+
+	var replayed []*webhookDelivery
+
+	if len(ids) == 0 {
+		replayed = d.sortedDeadLetters()
+	}
+
+	for _, id := range ids {
+		if delivery, ok := d.deadLetters[id]; ok {
+			replayed = append(replayed, delivery)
+		}
+	}
+
+	for _, delivery := range replayed {
+		delete(d.deadLetters, delivery.ID)
+		delivery.Attempts = 0
+		delivery.LastError = ""
+		delivery.FailedAt = time.Time{}
+	}
+
+	d.rewriteDeadLetters()
+	d.mu.Unlock()
+
+	for _, delivery := range replayed {
+		d.enqueue(delivery)
+	}
+
+	return replayed
+}
+
+// listDeadLetters returns a copy of the dead letters, oldest first, that
+// stays valid once they are replayed.
+func (d *webhookDispatcher) listDeadLetters() []webhookDelivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deliveries := []webhookDelivery{}
+
+	for _, delivery := range d.sortedDeadLetters() {
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return deliveries
+}
+
+func httpListDeadLetters(c *gin.Context) {
+	if webhooks == nil {
+		c.JSON(http.StatusOK, gin.H{"dead_letters": []webhookDelivery{}})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"dead_letters": webhooks.listDeadLetters()})
+}
+
+func httpReplayDeadLetters(c *gin.Context) {
+	var ids []string
+
+	if id := c.Param("id"); id != "" {
+		ids = append(ids, id)
+	}
+
+	var replayed []*webhookDelivery
+
+	if webhooks != nil {
+		replayed = webhooks.replay(ids...)
+	}
+
+	if len(ids) > 0 && len(replayed) == 0 {
+		httpError(c, revtc.ErrNotFound)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"replayed": len(replayed)})
+}
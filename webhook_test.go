@@ -0,0 +1,234 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"github.com/united-drivers/go-revtc/revtc"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookReceiver is a webhook endpoint answering with status and keeping
+// the requests it got.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status = status
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.requests)
+}
+
+func newTestDispatcher(urls ...string) *webhookDispatcher {
+	return &webhookDispatcher{
+		urls:          urls,
+		secret:        []byte("secret"),
+		maxAttempts:   3,
+		retryDelay:    time.Millisecond,
+		retryMaxDelay: 10 * time.Millisecond,
+		httpClient:    &http.Client{Timeout: time.Second},
+		queue:         make(chan *webhookDelivery, 10),
+		deadLetters:   map[string]*webhookDelivery{},
+	}
+}
+
+// waitFor polls condition for up to a second.
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !condition(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	d := newTestDispatcher(server.URL)
+	delivery := &webhookDelivery{ID: "1", URL: server.URL, Event: "removed", Payload: []byte(`{"type":"removed"}`)}
+
+	if _, err := d.post(delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	req, body := receiver.requests[0], receiver.bodies[0]
+
+	// what a receiver is expected to check
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(req.Header.Get(webhookTimestampHeader) + "." + string(body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := req.Header.Get(webhookSignatureHeader); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+
+	if req.Header.Get(webhookEventHeader) != "removed" || req.Header.Get(webhookDeliveryHeader) != "1" {
+		t.Errorf("headers = %v", req.Header)
+	}
+
+	if sign([]byte("secret"), "1", []byte("a")) == sign([]byte("other"), "1", []byte("a")) {
+		t.Error("signature does not depend on the secret")
+	}
+}
+
+func TestWebhookPost(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	d := newTestDispatcher(server.URL)
+
+	tests := []struct {
+		status int
+		fails  bool
+		retry  bool
+	}{
+		{http.StatusOK, false, false},
+		{http.StatusNoContent, false, false},
+		{http.StatusInternalServerError, true, true},
+		{http.StatusBadGateway, true, true},
+		{http.StatusRequestTimeout, true, true},
+		{http.StatusTooManyRequests, true, true},
+		{http.StatusBadRequest, true, false},
+		{http.StatusNotFound, true, false},
+		{http.StatusGone, true, false},
+	}
+
+	for _, test := range tests {
+		receiver.setStatus(test.status)
+
+		retry, err := d.post(&webhookDelivery{ID: "1", URL: server.URL, Payload: []byte("{}")})
+
+		if (err != nil) != test.fails || retry != test.retry {
+			t.Errorf("status %d: retry = %v, error = %v", test.status, retry, err)
+		}
+	}
+
+	// an unreachable URL is worth retrying
+	server.Close()
+
+	if retry, err := d.post(&webhookDelivery{ID: "1", URL: server.URL, Payload: []byte("{}")}); err == nil || !retry {
+		t.Errorf("unreachable URL: retry = %v, error = %v", retry, err)
+	}
+}
+
+func TestWebhookDeadLetterAndReplay(t *testing.T) {
+	failing := &webhookReceiver{status: http.StatusServiceUnavailable}
+	failingServer := httptest.NewServer(failing)
+	defer failingServer.Close()
+
+	healthy := &webhookReceiver{status: http.StatusOK}
+	healthyServer := httptest.NewServer(healthy)
+	defer healthyServer.Close()
+
+	d := newTestDispatcher(failingServer.URL, healthyServer.URL)
+	d.retryDelay = 50 * time.Millisecond
+	d.retryMaxDelay = 50 * time.Millisecond
+
+	go d.work()
+	defer close(d.queue)
+
+	d.publish(revtc.WatchEvent{Type: revtc.WatchEntryRemoved, Identifier: "732829320"})
+
+	// a single worker still delivers to the healthy URL while the failing
+	// one waits for its retries
+	waitFor(t, "the healthy delivery", func() bool { return healthy.count() == 1 })
+
+	if failing.count() != 1 {
+		t.Errorf("%d attempts at the failing URL before its backoff elapsed", failing.count())
+	}
+
+	waitFor(t, "the dead letter", func() bool { return len(d.listDeadLetters()) == 1 })
+
+	deadLetter := d.listDeadLetters()[0]
+
+	if deadLetter.URL != failingServer.URL || deadLetter.Attempts != d.maxAttempts || deadLetter.FailedAt.IsZero() {
+		t.Errorf("dead letter = %+v", deadLetter)
+	}
+
+	if replayed := d.replay("unknown"); len(replayed) != 0 {
+		t.Errorf("replayed %d unknown deliveries", len(replayed))
+	}
+
+	failing.setStatus(http.StatusOK)
+
+	if replayed := d.replay(deadLetter.ID); len(replayed) != 1 {
+		t.Fatalf("replayed %d deliveries, want 1", len(replayed))
+	}
+
+	waitFor(t, "the replayed delivery", func() bool { return failing.count() == d.maxAttempts+1 })
+
+	if deadLetters := d.listDeadLetters(); len(deadLetters) != 0 {
+		t.Errorf("dead letters after replay = %+v", deadLetters)
+	}
+}
+
+func TestAdminRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := newRouter("token")
+
+	tests := []struct {
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{http.MethodGet, "/admin/webhooks/dead_letters", "", http.StatusUnauthorized},
+		{http.MethodGet, "/admin/webhooks/dead_letters", "token", http.StatusOK},
+		{http.MethodPost, "/admin/webhooks/replay", "token", http.StatusOK},
+		{http.MethodPost, "/admin/webhooks/dead_letters/unknown/replay", "token", http.StatusNotFound},
+		{http.MethodPut, "/watchlist/732829320", "", http.StatusUnauthorized},
+		{http.MethodDelete, "/watchlist/732829320", "wrong", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+
+		if test.token != "" {
+			req.Header.Set("Authorization", "Bearer "+test.token)
+		}
+
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		if res.Code != test.status {
+			t.Errorf("%s %s = %d, want %d", test.method, test.path, res.Code, test.status)
+		}
+	}
+}